@@ -0,0 +1,314 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+)
+
+// apiPathPlaceholder is the API path part that can't be resolved statically, e.g. the resource name.
+const apiPathPlaceholder = "{}"
+
+// apiPathMaxCallDepth is the max depth of the function calls that are followed to evaluate the API path.
+const apiPathMaxCallDepth = 8
+
+// apiPathFromValue evaluates the SSA value that constructs the API path, and returns the API path in its original casing,
+// which is to be normalized (see normalizeAPIPath).
+// The string constants, the concatenations, the fmt.Sprintf() calls and the (non standard library) function calls are
+// evaluated, while any other part (e.g. the function parameters, the struct fields) is regarded as a placeholder, e.g.
+//
+//	fmt.Sprintf("%s/eventhubs/%s", id.ID(), name) => "/subscriptions/{}/.../namespaces/{}/eventhubs/{}"
+func apiPathFromValue(v ssa.Value) string {
+	return evalAPIPath(v, nil, 0, map[ssa.Value]bool{})
+}
+
+// apiPathFromFunc evaluates the API path returned by the function, e.g. the ID() method of a resource ID type, in its
+// original casing (see apiPathFromValue).
+func apiPathFromFunc(fn *ssa.Function) string {
+	return evalAPIPathReturn(fn, nil, 0, map[ssa.Value]bool{})
+}
+
+// evalAPIPath evaluates the SSA value to a string, where the env binds the parameters of the function being evaluated
+// to the (evaluated) arguments of the call.
+func evalAPIPath(v ssa.Value, env map[*ssa.Parameter]string, depth int, seen map[ssa.Value]bool) string {
+	if seen[v] {
+		return apiPathPlaceholder
+	}
+	seen[v] = true
+	defer delete(seen, v)
+
+	switch v := v.(type) {
+	case *ssa.Const:
+		if v.Value != nil && v.Value.Kind() == constant.String {
+			return constant.StringVal(v.Value)
+		}
+	case *ssa.Parameter:
+		if s, ok := env[v]; ok {
+			return s
+		}
+	case *ssa.ChangeType:
+		return evalAPIPath(v.X, env, depth, seen)
+	case *ssa.MakeInterface:
+		return evalAPIPath(v.X, env, depth, seen)
+	case *ssa.Convert:
+		if isStringType(v.X.Type()) {
+			return evalAPIPath(v.X, env, depth, seen)
+		}
+	case *ssa.BinOp:
+		if v.Op == token.ADD && isStringType(v.Type()) {
+			return evalAPIPath(v.X, env, depth, seen) + evalAPIPath(v.Y, env, depth, seen)
+		}
+	case *ssa.Phi:
+		// The edges evaluated to different values are merged (see mergeAPIPaths), e.g. a path that is optionally
+		// suffixed in one branch keeps the common part.
+		var out string
+		for i, e := range v.Edges {
+			s := evalAPIPath(e, env, depth, seen)
+			if i == 0 {
+				out = s
+				continue
+			}
+			out = mergeAPIPaths(out, s)
+		}
+		if len(v.Edges) != 0 {
+			return out
+		}
+	case *ssa.Call:
+		return evalAPIPathCall(v, env, depth, seen)
+	}
+	return apiPathPlaceholder
+}
+
+// evalAPIPathCall evaluates the result of the static function call.
+func evalAPIPathCall(call *ssa.Call, env map[*ssa.Parameter]string, depth int, seen map[ssa.Value]bool) string {
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Signature.Results().Len() != 1 || depth >= apiPathMaxCallDepth {
+		return apiPathPlaceholder
+	}
+	if callee.Pkg != nil && callee.Pkg.Pkg.Path() == "fmt" && callee.Name() == "Sprintf" {
+		return evalAPIPathSprintf(call, env, depth, seen)
+	}
+	// The standard library functions (e.g. url.PathEscape()) are not followed.
+	if callee.Pkg == nil || !strings.Contains(strings.Split(callee.Pkg.Pkg.Path(), "/")[0], ".") || len(callee.Blocks) == 0 {
+		return apiPathPlaceholder
+	}
+
+	calleeEnv := map[*ssa.Parameter]string{}
+	for i, arg := range call.Call.Args {
+		if i < len(callee.Params) {
+			calleeEnv[callee.Params[i]] = evalAPIPath(arg, env, depth, seen)
+		}
+	}
+	return evalAPIPathReturn(callee, calleeEnv, depth+1, seen)
+}
+
+// evalAPIPathReturn evaluates the (single) result of the function. The returns evaluated to different values are merged
+// (see mergeAPIPaths).
+func evalAPIPathReturn(fn *ssa.Function, env map[*ssa.Parameter]string, depth int, seen map[ssa.Value]bool) string {
+	var (
+		out   string
+		found bool
+	)
+	for _, b := range fn.Blocks {
+		ret, ok := b.Instrs[len(b.Instrs)-1].(*ssa.Return)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		s := evalAPIPath(ret.Results[0], env, depth, seen)
+		if found {
+			s = mergeAPIPaths(out, s)
+		}
+		out, found = s, true
+	}
+	if !found {
+		return apiPathPlaceholder
+	}
+	return out
+}
+
+// mergeAPIPaths merges the API paths evaluated from different branches segment by segment, where only the differing
+// segments are replaced by the placeholder. If the paths have different numbers of segments, the differing tail is
+// replaced by a single placeholder, e.g.
+//
+//	"/foos/{}/bars" + "/foos/{}/bazs" => "/foos/{}/{}"
+//	"/foos/{}" + "/foos/{}/bars/{}" => "/foos/{}/{}"
+func mergeAPIPaths(a, b string) string {
+	if a == b {
+		return a
+	}
+	if a == apiPathPlaceholder || b == apiPathPlaceholder {
+		return apiPathPlaceholder
+	}
+	x, y := strings.Split(a, "/"), strings.Split(b, "/")
+	n := min(len(x), len(y))
+	out := make([]string, 0, n+1)
+	for i := range n {
+		if x[i] == y[i] {
+			out = append(out, x[i])
+			continue
+		}
+		out = append(out, apiPathPlaceholder)
+	}
+	if len(x) != len(y) {
+		out = append(out, apiPathPlaceholder)
+	}
+	return strings.Join(out, "/")
+}
+
+// evalAPIPathSprintf evaluates the fmt.Sprintf() call, where each verb of the format string is replaced by the evaluated
+// argument.
+func evalAPIPathSprintf(call *ssa.Call, env map[*ssa.Parameter]string, depth int, seen map[ssa.Value]bool) string {
+	args := call.Call.Args
+	format := evalAPIPath(args[0], env, depth, seen)
+	var vargs []string
+	if slice, ok := args[1].(*ssa.Slice); ok {
+		vargs = evalAPIPathVarargs(slice, env, depth, seen)
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			sb.WriteByte(format[i])
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			sb.WriteByte('%')
+			i++
+			continue
+		}
+		// Skip the flags, width and precision, until the verb.
+		for i+1 < len(format) && !isLetter(format[i+1]) {
+			i++
+		}
+		i++
+		if len(vargs) == 0 {
+			sb.WriteString(apiPathPlaceholder)
+			continue
+		}
+		sb.WriteString(vargs[0])
+		vargs = vargs[1:]
+	}
+	return sb.String()
+}
+
+// evalAPIPathVarargs evaluates the variadic arguments, which are built as below:
+//
+//	t0 = new [2]any (varargs)
+//	t1 = &t0[0:int]
+//	t2 = make any <- string (x)
+//	*t1 = t2
+//	...
+//	t5 = slice t0[:]
+func evalAPIPathVarargs(slice *ssa.Slice, env map[*ssa.Parameter]string, depth int, seen map[ssa.Value]bool) []string {
+	alloc, ok := slice.X.(*ssa.Alloc)
+	if !ok {
+		return nil
+	}
+	array, ok := alloc.Type().(*types.Pointer).Elem().Underlying().(*types.Array)
+	if !ok {
+		return nil
+	}
+	out := make([]string, array.Len())
+	for i := range out {
+		out[i] = apiPathPlaceholder
+	}
+	for _, ref := range *alloc.Referrers() {
+		addr, ok := ref.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+		idx, ok := addr.Index.(*ssa.Const)
+		if !ok {
+			continue
+		}
+		i, ok := constant.Int64Val(idx.Value)
+		if !ok || i < 0 || int(i) >= len(out) {
+			continue
+		}
+		for _, ref := range *addr.Referrers() {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == addr {
+				out[i] = evalAPIPath(store.Val, env, depth, seen)
+			}
+		}
+	}
+	return out
+}
+
+// apiPathFromCallArg returns the API path (see apiPathFromValue) from the first argument of the call in the function fn, e.g.
+//
+//	autorest.WithPathParameters("/subscriptions/{subscriptionId}/providers/Microsoft.Foo/foos/{fooName}", pathParameters)
+//	autorest.WithPath(id.ID())
+func apiPathFromCallArg(pkg *packages.Package, fn *ssa.Function, call *ast.CallExpr) (string, error) {
+	v := callArgAt(fn, call.Lparen, 0)
+	if v == nil {
+		return "", fmt.Errorf("SSA value of the path at %s not found", position(pkg.Fset, call.Pos()))
+	}
+	return apiPathFromValue(v), nil
+}
+
+// storedValueAt returns the value stored at the position in the function (including its anonymous functions), e.g. the
+// value of a field of a composite literal, which is stored at the position of the colon of the key-value expression.
+// It returns nil if not found.
+func storedValueAt(fn *ssa.Function, pos token.Pos) ssa.Value {
+	var v ssa.Value
+	walkInstrs(fn, func(instr ssa.Instruction) bool {
+		if store, ok := instr.(*ssa.Store); ok && store.Pos() == pos {
+			v = store.Val
+			return false
+		}
+		return true
+	})
+	return v
+}
+
+// callArgAt returns the idx-th (0-based) argument of the call at the position (i.e. the left parenthesis) in the function
+// (including its anonymous functions). It returns nil if not found.
+func callArgAt(fn *ssa.Function, lparen token.Pos, idx int) ssa.Value {
+	var v ssa.Value
+	walkInstrs(fn, func(instr ssa.Instruction) bool {
+		call, ok := instr.(*ssa.Call)
+		if !ok || call.Pos() != lparen {
+			return true
+		}
+		if idx < len(call.Call.Args) {
+			v = call.Call.Args[idx]
+		}
+		return false
+	})
+	return v
+}
+
+// walkInstrs walks the instructions of the function and its anonymous functions, until f returns false.
+func walkInstrs(fn *ssa.Function, f func(ssa.Instruction) bool) bool {
+	if fn == nil {
+		return true
+	}
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if !f(instr) {
+				return false
+			}
+		}
+	}
+	for _, af := range fn.AnonFuncs {
+		if !walkInstrs(af, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func isStringType(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsString != 0
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/ssa"
+)
+
+func TestAPIPathFromValue(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/apipathuser", []string{"."})
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+
+	cases := []struct {
+		name   string
+		expect string
+	}{
+		{name: "literal", expect: "/PROVIDERS/MICROSOFT.FOO/OPERATIONS"},
+		{name: "concat", expect: "/PROVIDERS/MICROSOFT.FOO/BARS/{}"},
+		{name: "constructedID", expect: "/SUBSCRIPTIONS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"},
+		{name: "sprintf", expect: "/SUBSCRIPTIONS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/BAZS/{}"},
+		{name: "helper", expect: "/PROVIDERS/MICROSOFT.FOO/BARS/BAR/START"},
+		{name: "unresolved", expect: "{}/BARS"},
+		{name: "branch", expect: "/SUBSCRIPTIONS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/{}"},
+		{name: "branchSegment", expect: "/SUBSCRIPTIONS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/{}/DEFAULT"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := pkgs[0].ssa.Func(tt.name)
+			require.NotNil(t, fn)
+			var ret *ssa.Return
+			for _, b := range fn.Blocks {
+				if r, ok := b.Instrs[len(b.Instrs)-1].(*ssa.Return); ok {
+					ret = r
+				}
+			}
+			require.NotNil(t, ret)
+			require.Equal(t, tt.expect, normalizeAPIPath(apiPathFromValue(ret.Results[0])))
+		})
+	}
+}
+
+func TestMergeAPIPaths(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		a, b   string
+		expect string
+	}{
+		{a: "/foos/{}", b: "/foos/{}", expect: "/foos/{}"},
+		{a: "/foos/{}/bars", b: "/foos/{}/bazs", expect: "/foos/{}/{}"},
+		{a: "/foos/a/bars", b: "/foos/b/bars", expect: "/foos/{}/bars"},
+		{a: "/foos/{}", b: "/foos/{}/bars/{}", expect: "/foos/{}/{}"},
+		{a: "{}", b: "/foos/{}", expect: "{}"},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.expect, mergeAPIPaths(c.a, c.b), "%s + %s", c.a, c.b)
+	}
+}
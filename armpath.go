@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// The scopes of the ARM API paths, i.e. where the resource is deployed at.
+const (
+	ARMScopeTenant          = "tenant"
+	ARMScopeSubscription    = "subscription"
+	ARMScopeResourceGroup   = "resource_group"
+	ARMScopeManagementGroup = "management_group"
+	// ARMScopeExtension is the scope of the extension resources, which are deployed at another resource (e.g. the "{scope}"
+	// of the role assignments, or the resource id of the parent resource).
+	ARMScopeExtension = "extension"
+)
+
+// ARMPath is the structured form of the ARM API path, in the original casing of the SDK, e.g.
+//
+//	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Foo/foos/{fooName}/listKeys
+//
+// has the scope of "resource_group", the provider namespace of "Microsoft.Foo", the resource types of ["foos"] and the
+// action of "listKeys".
+type ARMPath struct {
+	Scope             string            `json:"scope"`
+	ProviderNamespace string            `json:"provider_namespace"`
+	ResourceTypes     ResourceTypeChain `json:"resource_types,omitempty"`
+	// Action is the trailing segment of the POST operation that doesn't follow a resource name, e.g. "listKeys".
+	Action string `json:"action,omitempty"`
+}
+
+// ResourceType returns the ARM resource type, e.g. "Microsoft.Foo/foos/bars". It returns empty string if there is no
+// resource type, e.g. the provider actions.
+func (p ARMPath) ResourceType() string {
+	if p.ProviderNamespace == "" || p.ResourceTypes == "" {
+		return ""
+	}
+	return p.ProviderNamespace + "/" + string(p.ResourceTypes)
+}
+
+func (p ARMPath) less(o ARMPath) bool {
+	if p.Scope != o.Scope {
+		return p.Scope < o.Scope
+	}
+	if p.ProviderNamespace != o.ProviderNamespace {
+		return p.ProviderNamespace < o.ProviderNamespace
+	}
+	if p.ResourceTypes != o.ResourceTypes {
+		return p.ResourceTypes < o.ResourceTypes
+	}
+	return p.Action < o.Action
+}
+
+// ResourceTypeChain is the chain of the (nested) resource types, from the outermost one, e.g. "foos/bars" for the resource
+// type "Microsoft.Foo/foos/bars". It is stored as a slash separated string, to keep the APIOperation comparable, and is
+// encoded as a JSON array.
+type ResourceTypeChain string
+
+// Values returns the resource types of the chain.
+func (c ResourceTypeChain) Values() []string {
+	if c == "" {
+		return nil
+	}
+	return strings.Split(string(c), "/")
+}
+
+func (c ResourceTypeChain) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Values())
+}
+
+func (c *ResourceTypeChain) UnmarshalJSON(b []byte) error {
+	var v []string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*c = ResourceTypeChain(strings.Join(v, "/"))
+	return nil
+}
+
+// parseARMPath parses the (not normalized) API path of the operation kind to the ARMPath. The operation kind tells whether
+// a trailing segment is an action (i.e. POST), or a collection of the resource type (e.g. GET for listing).
+// It returns a zero ARMPath if the path is not an ARM API path.
+func parseARMPath(p string, kind OperationKind) ARMPath {
+	var segs []string
+	for seg := range strings.SplitSeq(p, "/") {
+		if seg != "" {
+			segs = append(segs, seg)
+		}
+	}
+	if len(segs) == 0 {
+		return ARMPath{}
+	}
+
+	// The resource provider namespace is the one follows the last "providers" segment, which handles the extension resources.
+	// For API paths without the provider namespace (e.g. "/subscriptions/{}/resourceGroups/{}"), they belong to "Microsoft.Resources",
+	// whose resource types start from the tenant level "subscriptions" (e.g. "subscriptions/resourceGroups"), the same as
+	// the RBAC actions.
+	var (
+		out  ARMPath
+		rest []string
+	)
+	idx := -1
+	for i := len(segs) - 2; i >= 0; i-- {
+		if strings.EqualFold(segs[i], "providers") {
+			idx = i
+			break
+		}
+	}
+	if idx != -1 {
+		out.ProviderNamespace = segs[idx+1]
+		out.Scope = armPathScope(segs[:idx])
+		rest = segs[idx+2:]
+	} else {
+		if !strings.EqualFold(segs[0], "subscriptions") {
+			return ARMPath{}
+		}
+		out.ProviderNamespace = "Microsoft.Resources"
+		out.Scope = ARMScopeTenant
+		rest = segs
+	}
+
+	// The rest segments are pairs of resource type and name. A trailing segment without a name is either a collection
+	// (e.g. GET list), or an action (e.g. POST listKeys).
+	var types []string
+	for i := 0; i < len(rest); i += 2 {
+		if i == len(rest)-1 && kind == OperationKindPost {
+			out.Action = rest[i]
+			break
+		}
+		types = append(types, rest[i])
+	}
+	out.ResourceTypes = ResourceTypeChain(strings.Join(types, "/"))
+	return out
+}
+
+// armPathScope returns the scope of the API path segments that precede the last "providers" segment.
+func armPathScope(segs []string) string {
+	is := func(names ...string) bool {
+		if len(segs) != len(names) {
+			return false
+		}
+		for i, name := range names {
+			if name != "" && !strings.EqualFold(segs[i], name) {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case len(segs) == 0:
+		return ARMScopeTenant
+	case is("subscriptions", ""):
+		return ARMScopeSubscription
+	case is("subscriptions", "", "resourceGroups", ""):
+		return ARMScopeResourceGroup
+	case is("providers", "Microsoft.Management", "managementGroups", ""):
+		return ARMScopeManagementGroup
+	default:
+		return ARMScopeExtension
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseARMPath(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name   string
+		kind   OperationKind
+		path   string
+		expect ARMPath
+	}{
+		{
+			name:   "resource group",
+			kind:   OperationKindGet,
+			path:   "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Foo/foos/{fooName}",
+			expect: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"},
+		},
+		{
+			name:   "nested resource type",
+			kind:   OperationKindPut,
+			path:   "/subscriptions/{}/resourceGroups/{}/providers/Microsoft.Foo/foos/{}/bars/{}",
+			expect: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos/bars"},
+		},
+		{
+			name:   "collection",
+			kind:   OperationKindGet,
+			path:   "/subscriptions/{}/providers/Microsoft.Foo/foos",
+			expect: ARMPath{Scope: ARMScopeSubscription, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"},
+		},
+		{
+			name:   "action",
+			kind:   OperationKindPost,
+			path:   "/subscriptions/{}/resourceGroups/{}/providers/Microsoft.Foo/foos/{}/listKeys",
+			expect: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos", Action: "listKeys"},
+		},
+		{
+			name:   "provider action",
+			kind:   OperationKindPost,
+			path:   "/subscriptions/{}/providers/Microsoft.Foo/checkNameAvailability",
+			expect: ARMPath{Scope: ARMScopeSubscription, ProviderNamespace: "Microsoft.Foo", Action: "checkNameAvailability"},
+		},
+		{
+			name:   "tenant",
+			kind:   OperationKindGet,
+			path:   "/providers/Microsoft.Foo/operations",
+			expect: ARMPath{Scope: ARMScopeTenant, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "operations"},
+		},
+		{
+			name:   "management group",
+			kind:   OperationKindPut,
+			path:   "/providers/Microsoft.Management/managementGroups/{groupId}/providers/Microsoft.Authorization/policyDefinitions/{}",
+			expect: ARMPath{Scope: ARMScopeManagementGroup, ProviderNamespace: "Microsoft.Authorization", ResourceTypes: "policyDefinitions"},
+		},
+		{
+			name:   "management group itself",
+			kind:   OperationKindGet,
+			path:   "/providers/Microsoft.Management/managementGroups/{groupId}",
+			expect: ARMPath{Scope: ARMScopeTenant, ProviderNamespace: "Microsoft.Management", ResourceTypes: "managementGroups"},
+		},
+		{
+			name:   "extension",
+			kind:   OperationKindPut,
+			path:   "/{scope}/providers/Microsoft.Authorization/roleAssignments/{roleAssignmentName}",
+			expect: ARMPath{Scope: ARMScopeExtension, ProviderNamespace: "Microsoft.Authorization", ResourceTypes: "roleAssignments"},
+		},
+		{
+			name:   "extension of a resource",
+			kind:   OperationKindGet,
+			path:   "/subscriptions/{}/resourceGroups/{}/providers/Microsoft.Foo/foos/{}/providers/Microsoft.Insights/diagnosticSettings/{}",
+			expect: ARMPath{Scope: ARMScopeExtension, ProviderNamespace: "Microsoft.Insights", ResourceTypes: "diagnosticSettings"},
+		},
+		{
+			name:   "resource group itself",
+			kind:   OperationKindDelete,
+			path:   "/subscriptions/{}/resourcegroups/{}",
+			expect: ARMPath{Scope: ARMScopeTenant, ProviderNamespace: "Microsoft.Resources", ResourceTypes: "subscriptions/resourcegroups"},
+		},
+		{
+			name:   "subscriptions",
+			kind:   OperationKindGet,
+			path:   "/subscriptions",
+			expect: ARMPath{Scope: ARMScopeTenant, ProviderNamespace: "Microsoft.Resources", ResourceTypes: "subscriptions"},
+		},
+		{
+			name: "not ARM",
+			kind: OperationKindGet,
+			path: "/applications/{}",
+		},
+		{
+			name: "unresolved",
+			kind: OperationKindGet,
+			path: "{}",
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, parseARMPath(tt.path, tt.kind))
+		})
+	}
+}
+
+func TestARMPathJSON(t *testing.T) {
+	t.Parallel()
+	op := APIOperation{
+		Kind:    OperationKindGet,
+		Version: "2025-04-01",
+		Path:    "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/BARS/{}",
+		ARMPath: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos/bars"},
+	}
+	b, err := json.Marshal(op)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "kind": "GET",
+  "version": "2025-04-01",
+  "path": "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/BARS/{}",
+  "is_lro": false,
+  "arm_path": {
+    "scope": "resource_group",
+    "provider_namespace": "Microsoft.Foo",
+    "resource_types": ["foos", "bars"]
+  }
+}`, string(b))
+
+	var got APIOperation
+	require.NoError(t, json.Unmarshal(b, &got))
+	require.Equal(t, op, got)
+
+	// The non-ARM operations don't have the arm_path.
+	b, err = json.Marshal(APIOperation{Kind: OperationKindGet, Version: "v1.0", Path: "/APPLICATIONS/{}"})
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "arm_path")
+}
@@ -1,14 +1,22 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"go/constant"
 	"go/types"
+	"log"
 	"maps"
 	"slices"
 	"sort"
 	"strings"
 
 	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/callgraph/rta"
+	"golang.org/x/tools/go/callgraph/vta"
 	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
 )
 
 type SSAVisitor struct {
@@ -73,7 +81,10 @@ func CallGraph(prog *ssa.Program) *callgraph.Graph {
 		if !types.IsInterface(T) {
 			mset := prog.MethodSets.MethodSet(T)
 			for i := 0; i < mset.Len(); i++ {
-				v.visit(v.cg.CreateNode(prog.MethodValue(mset.At(i))))
+				// The abstract methods (e.g. promoted from the embedded interfaces) have no function.
+				if fn := prog.MethodValue(mset.At(i)); fn != nil {
+					v.visit(v.cg.CreateNode(fn))
+				}
 			}
 		}
 	}
@@ -102,6 +113,111 @@ func CallGraph(prog *ssa.Program) *callgraph.Graph {
 	return v.cg
 }
 
+const (
+	CallGraphAlgorithmStatic = "static"
+	CallGraphAlgorithmCHA    = "cha"
+	CallGraphAlgorithmRTA    = "rta"
+	CallGraphAlgorithmVTA    = "vta"
+)
+
+// buildCallGraph builds the call graph of the whole program of the "pkgs", using the specified algorithm:
+//
+//   - static: Only the static calls are followed (see CallGraph)
+//   - cha: Class Hierarchy Analysis
+//   - rta: Rapid Type Analysis, rooted at the package-level functions and methods of the "pkgs"
+//   - vta: Variable Type Analysis, refined from the CHA call graph
+//
+// For the non-static algorithms, the anonymous functions are additionally regarded as called by their parent functions,
+// as they are mostly called via functions that are trimmed later (e.g. pluginsdk.Retry()).
+// If pkgPathPrefixes is not empty, the call graph is trimmed to only keep the functions defined in these packages.
+func buildCallGraph(pkgs Packages, algorithm string, pkgPathPrefixes []string) (*callgraph.Graph, error) {
+	log.Printf("Build call graph (%s): begin\n", algorithm)
+	defer log.Printf("Build call graph (%s): end\n", algorithm)
+
+	if len(pkgs) == 0 {
+		return nil, errors.New("no package specified")
+	}
+	prog := pkgs[0].ssa.Prog
+
+	var graph *callgraph.Graph
+	switch algorithm {
+	case CallGraphAlgorithmStatic:
+		graph = CallGraph(prog)
+	case CallGraphAlgorithmCHA:
+		graph = cha.CallGraph(prog)
+		addAnonFuncEdges(graph)
+	case CallGraphAlgorithmRTA:
+		graph = rta.Analyze(rootFunctions(pkgs), true).CallGraph
+		addAnonFuncEdges(graph)
+	case CallGraphAlgorithmVTA:
+		graph = vta.CallGraph(ssautil.AllFunctions(prog), cha.CallGraph(prog))
+		addAnonFuncEdges(graph)
+	default:
+		return nil, fmt.Errorf("unknown call graph algorithm %q", algorithm)
+	}
+
+	if len(pkgPathPrefixes) != 0 {
+		trimCallGraph(graph, pkgPathPrefixes)
+	}
+	return graph, nil
+}
+
+// rootFunctions returns the package-level functions and the methods of the package-level types of the "pkgs".
+func rootFunctions(pkgs Packages) []*ssa.Function {
+	var roots []*ssa.Function
+	for _, pkg := range pkgs {
+		prog := pkg.ssa.Prog
+		methodsOf := func(T types.Type) {
+			mset := prog.MethodSets.MethodSet(T)
+			for i := 0; i < mset.Len(); i++ {
+				if f := prog.MethodValue(mset.At(i)); f != nil {
+					roots = append(roots, f)
+				}
+			}
+		}
+		for _, mem := range pkg.ssa.Members {
+			switch mem := mem.(type) {
+			case *ssa.Function:
+				if mem.TypeParams() == nil {
+					roots = append(roots, mem)
+				}
+			case *ssa.Type:
+				if !types.IsInterface(mem.Type()) {
+					if named, ok := mem.Type().(*types.Named); ok && named.TypeParams() == nil {
+						methodsOf(named)
+						methodsOf(types.NewPointer(named))
+					}
+				}
+			}
+		}
+	}
+	return roots
+}
+
+// addAnonFuncEdges adds an edge from each function in the graph to each of its anonymous functions, if not exist.
+// As the call site is unknown, the edge has no site.
+func addAnonFuncEdges(graph *callgraph.Graph) {
+	fnodes := slices.Collect(maps.Values(graph.Nodes))
+	for len(fnodes) != 0 {
+		fnode := fnodes[0]
+		fnodes = fnodes[1:]
+		if fnode.Func == nil {
+			continue
+		}
+		for _, af := range fnode.Func.AnonFuncs {
+			if slices.ContainsFunc(fnode.Out, func(e *callgraph.Edge) bool { return e.Callee.Func == af }) {
+				continue
+			}
+			_, exists := graph.Nodes[af]
+			gnode := graph.CreateNode(af)
+			callgraph.AddEdge(fnode, nil, gnode)
+			if !exists {
+				fnodes = append(fnodes, gnode)
+			}
+		}
+	}
+}
+
 func trimCallGraph(graph *callgraph.Graph, pkgPathPrefixes []string) {
 	//graph.DeleteSyntheticNodes() // This takes a lot of time...
 	oldNodes := map[*ssa.Function]*callgraph.Node{}
@@ -130,28 +246,240 @@ func trimCallGraph(graph *callgraph.Graph, pkgPathPrefixes []string) {
 	}
 }
 
-type APIOperationMap map[APIOperation]struct{}
+// APIOperationMap is a set of the API operations, keyed by APIOperation.key. For the API operations that only differ in the
+// ARMPath casing, the one with the smallest ARMPath is kept, to make it deterministic.
+type APIOperationMap map[APIOperation]APIOperation
+
+// Add adds the API operation to the set, and returns the one kept in the set.
+func (m APIOperationMap) Add(op APIOperation) APIOperation {
+	k := op.key()
+	if old, ok := m[k]; ok && !op.ARMPath.less(old.ARMPath) {
+		return old
+	}
+	m[k] = op
+	return op
+}
 
 func (m APIOperationMap) ToList() APIOperations {
-	l := APIOperations(slices.Collect(maps.Keys(m)))
+	l := APIOperations(slices.Collect(maps.Values(m)))
 	sort.Sort(l)
 	return l
 }
 
-func resReachSDK(graph *callgraph.Graph, resFunc *ssa.Function, sdkFuncs map[*ssa.Function]APIOperation) []APIOperation {
+// CallSite is a call edge in the call path from a resource function to a SDK function.
+type CallSite struct {
+	Caller string `json:"caller"`
+	Callee string `json:"callee"`
+	// Position is the "file:line" of the call site. It is empty for the edges that are added for the anonymous functions
+	// without a known call site.
+	Position string `json:"position,omitempty"`
+}
+
+// OperationEvidence is the call path that leads a resource function to an API operation.
+type OperationEvidence struct {
+	Operation APIOperation `json:"operation"`
+	CallPath  []CallSite   `json:"call_path"`
+}
+
+type OperationEvidences []OperationEvidence
+
+// Union adds the evidences of the API operations that are not yet evidenced.
+func (e *OperationEvidences) Union(b OperationEvidences) {
+	for _, ev := range b {
+		if !slices.ContainsFunc(*e, func(x OperationEvidence) bool { return x.Operation == ev.Operation }) {
+			*e = append(*e, ev)
+		}
+	}
+}
+
+// newCallPath converts the path returned by the callgraph.PathSearch to the call sites.
+func newCallPath(path []*callgraph.Edge) []CallSite {
+	var sites []CallSite
+	for _, edge := range path {
+		site := CallSite{
+			Caller: edge.Caller.Func.String(),
+			Callee: edge.Callee.Func.String(),
+		}
+		if edge.Site != nil && edge.Site.Pos().IsValid() {
+			pos := edge.Caller.Func.Prog.Fset.Position(edge.Site.Pos())
+			site.Position = fmt.Sprintf("%s:%d", pos.Filename, pos.Line)
+		}
+		sites = append(sites, site)
+	}
+	return sites
+}
+
+// resReachSDK returns the API operations of the SDK functions that are reachable from the resource function. If withEvidence
+// is true, the shortest call path found for each of them is also returned.
+func resReachSDK(graph *callgraph.Graph, resFunc *ssa.Function, sdkFuncs SDKFunctions, withEvidence bool) (APIOperations, OperationEvidences) {
+	srcNode := graph.Nodes[resFunc]
+	if srcNode == nil {
+		return nil, nil
+	}
+
 	// Using a map to unify multiple ssa functions end up to be the same APIOperation.
 	// E.g. A resource function can reach to DeleteThenPoll(), which in turns can reach to Delete(). Both corresponds to the same delete API operation.
 	//      In this case, only this operation will be recorded as a result.
 	m := APIOperationMap{}
-	for tgtFunc, apiOp := range sdkFuncs {
-		srcNode := graph.Nodes[resFunc]
-		targetNode := graph.Nodes[tgtFunc]
-		if targetNode == nil {
-			continue
+	// The call paths are keyed by APIOperation.key.
+	paths := map[APIOperation][]CallSite{}
+	pathTo := func(node *callgraph.Node, parents map[*callgraph.Node]*callgraph.Edge) []*callgraph.Edge {
+		var path []*callgraph.Edge
+		for edge := parents[node]; edge != nil; edge = parents[edge.Caller] {
+			path = append(path, edge)
+		}
+		slices.Reverse(path)
+		return path
+	}
+	// record records the API operation, and the call path to it if withEvidence. The call path is only built (from the parent
+	// edges) on demand, as formatting it is costly.
+	record := func(apiOp APIOperation, path func() []*callgraph.Edge) {
+		m.Add(apiOp)
+		if !withEvidence {
+			return
+		}
+		// Keep the shortest path (and the smaller one in the string form for the same length to make it deterministic).
+		k := apiOp.key()
+		callPath := newCallPath(path())
+		if old, ok := paths[k]; !ok || len(callPath) < len(old) ||
+			(len(callPath) == len(old) && fmt.Sprint(callPath) < fmt.Sprint(old)) {
+			paths[k] = callPath
+		}
+	}
+
+	// A single forward traversal (BFS) from the resource function, which records the edge that firstly reaches each node.
+	// As the nodes are visited in the order of their distances to the resource function, the recorded edges form the shortest paths.
+	parents := map[*callgraph.Node]*callgraph.Edge{srcNode: nil}
+	// The calls to the SDK functions whose API version is specified by the caller, which are resolved after the traversal.
+	var versionedCalls []*callgraph.Edge
+	queue := []*callgraph.Node{srcNode}
+	for len(queue) != 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		if fn, ok := sdkFuncs[node.Func]; ok && fn.versionParam == 0 {
+			record(fn.Operation, func() []*callgraph.Edge { return pathTo(node, parents) })
+		}
+
+		for _, edge := range node.Out {
+			if fn, ok := sdkFuncs[edge.Callee.Func]; ok && fn.versionParam != 0 {
+				versionedCalls = append(versionedCalls, edge)
+			}
+			if _, ok := parents[edge.Callee]; ok {
+				continue
+			}
+			parents[edge.Callee] = edge
+			queue = append(queue, edge.Callee)
+		}
+	}
+
+	// Report a separate operation for each API version resolved at the call sites, only following the callers that are
+	// reachable from the resource function.
+	for _, edge := range versionedCalls {
+		fn := sdkFuncs[edge.Callee.Func]
+		versions := []string{"unknown"}
+		if arg := callArg(edge, fn.versionParam); arg != nil {
+			versions = resolveConstStrings(graph, parents, arg, map[ssa.Value]bool{})
+		}
+		path := func() []*callgraph.Edge { return append(pathTo(edge.Caller, parents), edge) }
+		for _, version := range versions {
+			op := fn.Operation
+			op.Version = version
+			record(op, path)
 		}
-		if callgraph.PathSearch(srcNode, func(n *callgraph.Node) bool { return n == targetNode }) != nil {
-			m[apiOp] = struct{}{}
+	}
+
+	ops := m.ToList()
+	if !withEvidence {
+		return ops, nil
+	}
+	var evidences OperationEvidences
+	for _, op := range ops {
+		evidences = append(evidences, OperationEvidence{Operation: op, CallPath: paths[op.key()]})
+	}
+	return ops, evidences
+}
+
+// callArg returns the argument passed to the idx-th (1-based, excluding the receiver) parameter of the callee at the call site of the edge.
+// It returns nil if the call site is unknown.
+func callArg(edge *callgraph.Edge, idx int) ssa.Value {
+	if edge.Site == nil {
+		return nil
+	}
+	common := edge.Site.Common()
+	// The arguments of a static method call include the receiver, while the ones of an interface method call don't.
+	i := idx - 1 + len(common.Args) - edge.Callee.Func.Signature.Params().Len()
+	if i < 0 || i >= len(common.Args) {
+		return nil
+	}
+	return common.Args[i]
+}
+
+// resolveConstStrings follows the SSA value back to the string constants it can be. For the function parameters, the arguments
+// passed at the call sites from the reachable callers (i.e. the nodes in the reachable map) are followed. The value that can't
+// be resolved results in "unknown".
+func resolveConstStrings(graph *callgraph.Graph, reachable map[*callgraph.Node]*callgraph.Edge, v ssa.Value, seen map[ssa.Value]bool) []string {
+	if seen[v] {
+		return nil
+	}
+	seen[v] = true
+
+	var out []string
+	switch v := v.(type) {
+	case *ssa.Const:
+		if v.Value != nil && v.Value.Kind() == constant.String {
+			return []string{constant.StringVal(v.Value)}
+		}
+	case *ssa.ChangeType:
+		return resolveConstStrings(graph, reachable, v.X, seen)
+	case *ssa.Convert:
+		return resolveConstStrings(graph, reachable, v.X, seen)
+	case *ssa.Phi:
+		for _, e := range v.Edges {
+			out = append(out, resolveConstStrings(graph, reachable, e, seen)...)
+		}
+		return uniqueStrings(out)
+	case *ssa.Parameter:
+		fn := v.Parent()
+		node := graph.Nodes[fn]
+		idx := slices.Index(fn.Params, v)
+		if node == nil || idx == -1 {
+			break
+		}
+		// Convert to the 1-based index excluding the receiver, as expected by callArg.
+		idx = idx + 1 - (len(fn.Params) - fn.Signature.Params().Len())
+		for _, edge := range node.In {
+			if _, ok := reachable[edge.Caller]; !ok {
+				continue
+			}
+			arg := callArg(edge, idx)
+			if arg == nil {
+				out = append(out, "unknown")
+				continue
+			}
+			out = append(out, resolveConstStrings(graph, reachable, arg, seen)...)
+		}
+		if len(out) != 0 {
+			return uniqueStrings(out)
+		}
+	case *ssa.Call:
+		callee := v.Call.StaticCallee()
+		if callee == nil || callee.Signature.Results().Len() != 1 {
+			break
+		}
+		for _, b := range callee.Blocks {
+			if ret, ok := b.Instrs[len(b.Instrs)-1].(*ssa.Return); ok {
+				out = append(out, resolveConstStrings(graph, reachable, ret.Results[0], seen)...)
+			}
+		}
+		if len(out) != 0 {
+			return uniqueStrings(out)
 		}
 	}
-	return m.ToList()
+	return []string{"unknown"}
+}
+
+func uniqueStrings(l []string) []string {
+	slices.Sort(l)
+	return slices.Compact(l)
 }
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallGraph(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/callgraphuser", []string{"."})
+	require.NoError(t, err)
+
+	a := NewSDKAnalyzerAzure(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/azuresdk`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+
+	var (
+		armPathFoos = ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"}
+		opGet       = APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}", ARMPath: armPathFoos, ExpectedStatusCodes: "200"}
+		opPut       = APIOperation{Kind: OperationKindPut, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}", ARMPath: armPathFoos, IsLRO: true, ContentType: "application/json; charset=utf-8"}
+	)
+
+	cases := []struct {
+		algorithm string
+		expect    APIOperations
+	}{
+		{
+			algorithm: CallGraphAlgorithmStatic,
+			// The interface method call is not followed
+			expect: APIOperations{opPut},
+		},
+		{
+			algorithm: CallGraphAlgorithmCHA,
+			expect:    APIOperations{opGet, opPut},
+		},
+		{
+			algorithm: CallGraphAlgorithmRTA,
+			expect:    APIOperations{opGet, opPut},
+		},
+		{
+			algorithm: CallGraphAlgorithmVTA,
+			expect:    APIOperations{opGet, opPut},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.algorithm, func(t *testing.T) {
+			graph, err := buildCallGraph(pkgs, c.algorithm, []string{"github.com/magodo/aztfo/internal/testmodule"})
+			require.NoError(t, err)
+			ops, evidences := resReachSDK(graph, pkgs[0].ssa.Func("main"), funcs, true)
+			require.Equal(t, c.expect, ops)
+			require.Len(t, evidences, len(c.expect))
+			for _, ev := range evidences {
+				require.NotEmpty(t, ev.CallPath)
+				require.Equal(t, "github.com/magodo/aztfo/internal/testmodule/callgraphuser.main", ev.CallPath[0].Caller)
+			}
+		})
+	}
+
+	_, err = buildCallGraph(pkgs, "foo", nil)
+	require.Error(t, err)
+}
+
+func TestResReachSDKEvidence(t *testing.T) {
+	t.Parallel()
+	dir, err := filepath.Abs("./internal/testmodule/evidenceuser")
+	require.NoError(t, err)
+	pkgs, err := loadPackages(dir, []string{"."})
+	require.NoError(t, err)
+
+	a := NewSDKAnalyzerHashicorp(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/hashicorpsdk`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+
+	graph, err := buildCallGraph(pkgs, CallGraphAlgorithmStatic, nil)
+	require.NoError(t, err)
+
+	op := APIOperation{Kind: OperationKindPut, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}", ARMPath: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"}, IsLRO: true, ContentType: "application/json; charset=utf-8", ExpectedStatusCodes: "200,201,202"}
+	mainFunc := pkgs[0].ssa.Func("main")
+
+	ops, evidences := resReachSDK(graph, mainFunc, funcs, true)
+	require.Equal(t, APIOperations{op}, ops)
+	require.Equal(t, OperationEvidences{
+		{
+			Operation: op,
+			CallPath: []CallSite{
+				{
+					Caller:   "github.com/magodo/aztfo/internal/testmodule/evidenceuser.main",
+					Callee:   "github.com/magodo/aztfo/internal/testmodule/evidenceuser.create",
+					Position: filepath.Join(dir, "main.go") + ":10",
+				},
+				{
+					Caller:   "github.com/magodo/aztfo/internal/testmodule/evidenceuser.create",
+					Callee:   "(github.com/magodo/aztfo/internal/testmodule/hashicorpsdk.FooClientNative).CreateThenPoll",
+					Position: filepath.Join(dir, "main.go") + ":15",
+				},
+			},
+		},
+	}, evidences)
+
+	// The evidences are not recorded unless requested.
+	ops, evidences = resReachSDK(graph, mainFunc, funcs, false)
+	require.Equal(t, APIOperations{op}, ops)
+	require.Nil(t, evidences)
+}
+
+func TestResReachSDKAPIVersion(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/apiversionuser", []string{"."})
+	require.NoError(t, err)
+
+	a := NewSDKAnalyzerAzure(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/azuresdk`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+	require.Len(t, funcs, 1)
+	for _, fn := range funcs {
+		require.Equal(t, "unknown", fn.Operation.Version)
+		require.Equal(t, 3, fn.versionParam)
+	}
+
+	opGet := func(version string) APIOperation {
+		return APIOperation{Kind: OperationKindGet, Version: version, Path: "/{}", ExpectedStatusCodes: "200"}
+	}
+
+	graph, err := buildCallGraph(pkgs, CallGraphAlgorithmStatic, []string{"github.com/magodo/aztfo/internal/testmodule"})
+	require.NoError(t, err)
+
+	ops, evidences := resReachSDK(graph, pkgs[0].ssa.Func("main"), funcs, true)
+	require.Equal(t, APIOperations{opGet("2021-01-01"), opGet("2022-02-02"), opGet("2023-03-03")}, ops)
+	require.Len(t, evidences, 3)
+	require.Len(t, evidences[0].CallPath, 1)
+	require.Len(t, evidences[1].CallPath, 2)
+
+	// No evidence is built unless requested.
+	ops, evidences = resReachSDK(graph, pkgs[0].ssa.Func("other"), funcs, false)
+	require.Equal(t, APIOperations{opGet("2099-01-01")}, ops)
+	require.Nil(t, evidences)
+}
+
+func TestAPIOperationMapAdd(t *testing.T) {
+	t.Parallel()
+	const path = "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}"
+	var (
+		lower = APIOperation{Kind: OperationKindGet, Version: "2020-06-01", Path: path, ARMPath: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "microsoft.resources", ResourceTypes: "resourcegroups"}}
+		upper = APIOperation{Kind: OperationKindGet, Version: "2020-06-01", Path: path, ARMPath: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Resources", ResourceTypes: "resourceGroups"}}
+		other = APIOperation{Kind: OperationKindGet, Version: "2021-04-01", Path: path, ARMPath: upper.ARMPath}
+	)
+
+	// The operations that only differ in the ARMPath casing are deduplicated, regardless of the order they are added.
+	for _, ops := range []APIOperations{{lower, upper, other}, {other, upper, lower}} {
+		m := APIOperationMap{}
+		for _, op := range ops {
+			m.Add(op)
+		}
+		require.Equal(t, APIOperations{upper, other}, m.ToList())
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfile is the built-in profile used when no config is specified.
+const DefaultProfile = "azurerm"
+
+//go:embed profiles/*.yaml
+var profiles embed.FS
+
+// Config is the provider specific rules to discover the resources and the SDK API functions.
+type Config struct {
+	// PackagePathPrefixes are the package path prefixes of the functions that are included in the call graph.
+	PackagePathPrefixes []string `yaml:"package_path_prefixes"`
+	// ServicePackagePattern is the regexp of the service package paths, where the resources are registered.
+	ServicePackagePattern string `yaml:"service_package_pattern"`
+	// Resource is the rules to find the resources in the service packages.
+	Resource ResourceRules `yaml:"resource"`
+	// SDKs are the SDKs that invoke the API operations.
+	SDKs []SDKConfig `yaml:"sdks"`
+	// Permissions optionally maps the API operations to the permissions required, which are then recorded per verb.
+	Permissions PermissionRules `yaml:"permissions"`
+	// SensitiveOperations are the additional rules to tag the sensitive API operations, on top of the built-in ones.
+	SensitiveOperations SensitiveRules `yaml:"sensitive_operations"`
+
+	servicePackageRegexp *regexp.Regexp
+}
+
+// ResourceRules is the rules to find the resources in the service packages.
+type ResourceRules struct {
+	// Registrations are the type names of the service registration, e.g. "Registration".
+	Registrations []string `yaml:"registrations"`
+	// UntypedSDKPackage is the package name of the untyped plugin SDK, as referenced in the service packages, e.g. "pluginsdk".
+	UntypedSDKPackage string `yaml:"untyped_sdk_package"`
+	// TypedSDKPackage is the package name of the typed SDK, as referenced in the service packages, e.g. "sdk".
+	TypedSDKPackage string `yaml:"typed_sdk_package"`
+}
+
+const (
+	SDKKindAzure       = "azure"
+	SDKKindAzureTrack2 = "azure-track2"
+	SDKKindHashicorp   = "hashicorp"
+	SDKKindMSGraph     = "msgraph"
+	SDKKindDataPlane   = "dataplane"
+	SDKKindCustom      = "custom"
+)
+
+var sdkKinds = []string{SDKKindAzure, SDKKindAzureTrack2, SDKKindHashicorp, SDKKindMSGraph, SDKKindDataPlane, SDKKindCustom}
+
+// SDKConfig is a SDK that invokes the API operations.
+type SDKConfig struct {
+	// Kind is the kind of the SDK, which determines the SDK analyzer, i.e. one of sdkKinds.
+	Kind string `yaml:"kind"`
+	// PackagePattern is the regexp of the SDK package paths. It is optional for the "custom" kind, which defaults to the
+	// packages of the provider's own module.
+	PackagePattern string `yaml:"package_pattern"`
+	// Plane is "data" for the data-plane SDK, or empty for the management plane (i.e. ARM) SDK. The "dataplane" kind is
+	// always of the data plane.
+	Plane string `yaml:"plane"`
+	// Endpoint is the endpoint of the data-plane SDK, e.g. "https://{}.vault.azure.net".
+	Endpoint string `yaml:"endpoint"`
+
+	packageRegexp *regexp.Regexp
+}
+
+// loadConfig loads the config from either a built-in profile (by name), or a YAML/JSON file (by path).
+func loadConfig(nameOrPath string) (*Config, error) {
+	b, err := profiles.ReadFile("profiles/" + nameOrPath + ".yaml")
+	if err != nil {
+		b, err = os.ReadFile(nameOrPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading config %q: %v", nameOrPath, err)
+		}
+	}
+	// JSON is a subset of YAML, so both are decoded by the YAML decoder.
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("decoding config %q: %v", nameOrPath, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %q: %v", nameOrPath, err)
+	}
+	return &cfg, nil
+}
+
+// validate validates the config, and compiles the regexps.
+func (cfg *Config) validate() error {
+	if cfg.ServicePackagePattern == "" {
+		return fmt.Errorf("service_package_pattern is required")
+	}
+	p, err := regexp.Compile(cfg.ServicePackagePattern)
+	if err != nil {
+		return fmt.Errorf("compiling service_package_pattern: %v", err)
+	}
+	cfg.servicePackageRegexp = p
+
+	if len(cfg.Resource.Registrations) == 0 {
+		return fmt.Errorf("resource.registrations is required")
+	}
+	if cfg.Resource.UntypedSDKPackage == "" {
+		return fmt.Errorf("resource.untyped_sdk_package is required")
+	}
+	if cfg.Resource.TypedSDKPackage == "" {
+		return fmt.Errorf("resource.typed_sdk_package is required")
+	}
+
+	for i := range cfg.SDKs {
+		sdk := &cfg.SDKs[i]
+		if !slices.Contains(sdkKinds, sdk.Kind) {
+			return fmt.Errorf("sdks[%d]: unknown kind %q, expect one of %v", i, sdk.Kind, sdkKinds)
+		}
+		switch {
+		case sdk.PackagePattern != "":
+			p, err := regexp.Compile(sdk.PackagePattern)
+			if err != nil {
+				return fmt.Errorf("sdks[%d]: compiling package_pattern: %v", i, err)
+			}
+			sdk.packageRegexp = p
+		case sdk.Kind != SDKKindCustom:
+			return fmt.Errorf("sdks[%d]: package_pattern is required", i)
+		}
+		if sdk.Kind == SDKKindDataPlane {
+			sdk.Plane = PlaneData
+		}
+		switch sdk.Plane {
+		case "":
+			if sdk.Endpoint != "" {
+				return fmt.Errorf("sdks[%d]: endpoint is only for the data plane", i)
+			}
+		case PlaneData:
+		default:
+			return fmt.Errorf("sdks[%d]: unknown plane %q, expect %q or empty", i, sdk.Plane, PlaneData)
+		}
+	}
+
+	for i := range cfg.Permissions {
+		rule := &cfg.Permissions[i]
+		if rule.PathPattern == "" {
+			return fmt.Errorf("permissions[%d]: path_pattern is required", i)
+		}
+		p, err := regexp.Compile(rule.PathPattern)
+		if err != nil {
+			return fmt.Errorf("permissions[%d]: compiling path_pattern: %v", i, err)
+		}
+		rule.pathRegexp = p
+	}
+
+	for i := range cfg.SensitiveOperations {
+		rule := &cfg.SensitiveOperations[i]
+		if rule.PathPattern == "" {
+			return fmt.Errorf("sensitive_operations[%d]: path_pattern is required", i)
+		}
+		if rule.Reason == "" {
+			return fmt.Errorf("sensitive_operations[%d]: reason is required", i)
+		}
+		p, err := regexp.Compile(rule.PathPattern)
+		if err != nil {
+			return fmt.Errorf("sensitive_operations[%d]: compiling path_pattern: %v", i, err)
+		}
+		rule.pathRegexp = p
+	}
+	return nil
+}
+
+// sensitiveRules returns the built-in sensitive rules, followed by the configured ones.
+func (cfg *Config) sensitiveRules() SensitiveRules {
+	return append(slices.Clone(builtinSensitiveRules), cfg.SensitiveOperations...)
+}
+
+// servicePackages returns the service packages among the pkgs.
+func (cfg *Config) servicePackages(pkgs Packages) Packages {
+	var servicePkgs Packages
+	for _, pkg := range pkgs {
+		if cfg.servicePackageRegexp.MatchString(pkg.pkg.PkgPath) {
+			servicePkgs = append(servicePkgs, pkg)
+		}
+	}
+	return servicePkgs
+}
+
+// sdkAnalyzers returns the SDK analyzers of the configured SDKs.
+func (cfg *Config) sdkAnalyzers() []SDKAnalyzer {
+	var analyzers []SDKAnalyzer
+	for _, sdk := range cfg.SDKs {
+		var analyzer SDKAnalyzer
+		switch sdk.Kind {
+		case SDKKindAzure:
+			analyzer = NewSDKAnalyzerAzure(sdk.packageRegexp)
+		case SDKKindAzureTrack2:
+			analyzer = NewSDKAnalyzerAzureTrack2(sdk.packageRegexp)
+		case SDKKindHashicorp:
+			analyzer = NewSDKAnalyzerHashicorp(sdk.packageRegexp)
+		case SDKKindMSGraph:
+			analyzer = NewSDKAnalyzerMSGraph(sdk.packageRegexp)
+		case SDKKindDataPlane:
+			analyzer = NewSDKAnalyzerDataPlane(sdk.packageRegexp)
+		case SDKKindCustom:
+			analyzer = NewSDKAnalyzerCustom(sdk.packageRegexp)
+		}
+		if sdk.Plane == PlaneData {
+			analyzer = dataPlaneSDKAnalyzer{SDKAnalyzer: analyzer, endpoint: sdk.Endpoint}
+		}
+		analyzers = append(analyzers, analyzer)
+	}
+	return analyzers
+}
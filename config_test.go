@@ -0,0 +1,142 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// defaultConfig loads the built-in default profile.
+func defaultConfig(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := loadConfig(DefaultProfile)
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := defaultConfig(t)
+	require.Equal(t, ResourceRules{
+		Registrations:     []string{"Registration", "autoRegistration"},
+		UntypedSDKPackage: "pluginsdk",
+		TypedSDKPackage:   "sdk",
+	}, cfg.Resource)
+	require.True(t, cfg.servicePackageRegexp.MatchString("github.com/hashicorp/terraform-provider-azurerm/internal/services/resource"))
+	require.False(t, cfg.servicePackageRegexp.MatchString("github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/client"))
+	analyzers := cfg.sdkAnalyzers()
+	require.Len(t, analyzers, 13)
+	require.IsType(t, &SDKAnalyzerAzure{}, analyzers[0])
+	require.Equal(t, dataPlaneSDKAnalyzer{SDKAnalyzer: analyzers[3].(dataPlaneSDKAnalyzer).SDKAnalyzer, endpoint: "https://{}.vault.azure.net"}, analyzers[3])
+	require.IsType(t, &SDKAnalyzerDataPlane{}, analyzers[7].(dataPlaneSDKAnalyzer).SDKAnalyzer)
+	require.IsType(t, &SDKAnalyzerCustom{}, analyzers[12])
+	// The custom SDK defaults to the provider's own module.
+	require.Nil(t, analyzers[12].PackagePattern())
+
+	dir := t.TempDir()
+	cases := []struct {
+		name   string
+		file   string
+		body   string
+		hasErr bool
+	}{
+		{
+			name: "yaml",
+			file: "config.yaml",
+			body: `
+package_path_prefixes: [github.com/foo/terraform-provider-foo]
+service_package_pattern: ^github.com/foo/terraform-provider-foo/services/\w+$
+resource:
+  registrations: [Registration]
+  untyped_sdk_package: schema
+  typed_sdk_package: typed
+sdks:
+  - kind: hashicorp
+    package_pattern: github.com/foo/sdk
+`,
+		},
+		{
+			name: "json",
+			file: "config.json",
+			body: `{
+  "package_path_prefixes": ["github.com/foo/terraform-provider-foo"],
+  "service_package_pattern": "^github.com/foo/terraform-provider-foo/services/\\w+$",
+  "resource": {"registrations": ["Registration"], "untyped_sdk_package": "schema", "typed_sdk_package": "typed"},
+  "sdks": [{"kind": "hashicorp", "package_pattern": "github.com/foo/sdk"}]
+}`,
+		},
+		{
+			name:   "unknown field",
+			file:   "unknown_field.yaml",
+			body:   "service_package_patterns: foo\n",
+			hasErr: true,
+		},
+		{
+			name: "unknown sdk kind",
+			file: "unknown_sdk_kind.yaml",
+			body: `
+service_package_pattern: foo
+resource: {registrations: [Registration], untyped_sdk_package: pluginsdk, typed_sdk_package: sdk}
+sdks: [{kind: foo, package_pattern: foo}]
+`,
+			hasErr: true,
+		},
+		{
+			name: "endpoint of management plane",
+			file: "endpoint_of_management_plane.yaml",
+			body: `
+service_package_pattern: foo
+resource: {registrations: [Registration], untyped_sdk_package: pluginsdk, typed_sdk_package: sdk}
+sdks: [{kind: azure, package_pattern: foo, endpoint: "https://{}.vault.azure.net"}]
+`,
+			hasErr: true,
+		},
+		{
+			name: "missing package pattern",
+			file: "missing_package_pattern.yaml",
+			body: `
+service_package_pattern: foo
+resource: {registrations: [Registration], untyped_sdk_package: pluginsdk, typed_sdk_package: sdk}
+sdks: [{kind: hashicorp}]
+`,
+			hasErr: true,
+		},
+		{
+			name: "invalid regexp",
+			file: "invalid_regexp.yaml",
+			body: `
+service_package_pattern: "("
+resource: {registrations: [Registration], untyped_sdk_package: pluginsdk, typed_sdk_package: sdk}
+`,
+			hasErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(dir, c.file)
+			require.NoError(t, os.WriteFile(path, []byte(c.body), 0644))
+			cfg, err := loadConfig(path)
+			if c.hasErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{"github.com/foo/terraform-provider-foo"}, cfg.PackagePathPrefixes)
+			require.Equal(t, ResourceRules{
+				Registrations:     []string{"Registration"},
+				UntypedSDKPackage: "schema",
+				TypedSDKPackage:   "typed",
+			}, cfg.Resource)
+			require.True(t, cfg.servicePackageRegexp.MatchString("github.com/foo/terraform-provider-foo/services/bar"))
+			analyzers := cfg.sdkAnalyzers()
+			require.Len(t, analyzers, 1)
+			require.Equal(t, "github.com/foo/sdk", analyzers[0].PackagePattern().String())
+		})
+	}
+
+	_, err := loadConfig(filepath.Join(dir, "not_exist.yaml"))
+	require.Error(t, err)
+}
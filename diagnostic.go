@@ -0,0 +1,105 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Diagnostic records a failure of analyzing an item (e.g. a resource or a SDK method).
+type Diagnostic struct {
+	Resource  string `json:"resource,omitempty"`
+	SDKMethod string `json:"sdk_method,omitempty"`
+	// Position is the source position of the item, in the form of "file:line:column".
+	Position string `json:"position,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+func (d Diagnostic) Error() string {
+	var segs []string
+	if d.Resource != "" {
+		segs = append(segs, "resource "+d.Resource)
+	}
+	if d.SDKMethod != "" {
+		segs = append(segs, "SDK method "+d.SDKMethod)
+	}
+	if d.Position != "" {
+		segs = append(segs, d.Position)
+	}
+	return strings.Join(append(segs, d.Reason), ": ")
+}
+
+// Diagnostics collects the diagnostics in the keep-going mode, where only the affected items are skipped.
+// A nil *Diagnostics means to fail on the first diagnostic.
+type Diagnostics struct {
+	mu    sync.Mutex
+	items []Diagnostic
+}
+
+// Handle records the diagnostic and returns nil in the keep-going mode, otherwise returns the diagnostic as an error.
+func (d *Diagnostics) Handle(diag Diagnostic) error {
+	if d == nil {
+		return diag
+	}
+	log.Printf("WARNING: %s\n", diag)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.items = append(d.items, diag)
+	return nil
+}
+
+// Items returns the recorded diagnostics, sorted by the resource, SDK method and then position.
+func (d *Diagnostics) Items() []Diagnostic {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	items := slices.Clone(d.items)
+	slices.SortStableFunc(items, func(x, y Diagnostic) int {
+		return cmp.Or(
+			cmp.Compare(x.Resource, y.Resource),
+			cmp.Compare(x.SDKMethod, y.SDKMethod),
+			cmp.Compare(x.Position, y.Position),
+		)
+	})
+	return items
+}
+
+// position returns the string form of the position, or an empty string if the position is not valid.
+func position(fset *token.FileSet, pos token.Pos) string {
+	if !pos.IsValid() {
+		return ""
+	}
+	return fset.Position(pos).String()
+}
+
+// sdkMethodName returns the name of the SDK method used in the diagnostics, e.g. "pkg.FooClient.Get".
+func sdkMethodName(method SDKMethod) string {
+	return fmt.Sprintf("%s.%s.%s", method.Recv.Obj().Pkg().Path(), method.Recv.Obj().Name(), method.MethodName)
+}
+
+// writeDiagnostics writes the diagnostics in JSON to the file. An empty path or "-" writes to the stderr.
+func writeDiagnostics(path string, items []Diagnostic) error {
+	if items == nil {
+		items = []Diagnostic{}
+	}
+	b, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal the diagnostics: %v", err)
+	}
+	if path == "" || path == "-" {
+		fmt.Fprintln(os.Stderr, string(b))
+		return nil
+	}
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return fmt.Errorf("writing the diagnostics: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnostics(t *testing.T) {
+	t.Parallel()
+	diag := Diagnostic{Resource: "azurerm_foo", Position: "foo.go:1:1", Reason: "unexpected expression"}
+
+	// Fail on the first diagnostic
+	var nilDiags *Diagnostics
+	err := nilDiags.Handle(diag)
+	require.EqualError(t, err, "resource azurerm_foo: foo.go:1:1: unexpected expression")
+	require.Nil(t, nilDiags.Items())
+
+	// Keep going
+	diags := &Diagnostics{}
+	require.NoError(t, diags.Handle(Diagnostic{SDKMethod: "pkg.FooClient.Get", Reason: "api path is not found"}))
+	require.NoError(t, diags.Handle(diag))
+	require.Equal(t,
+		[]Diagnostic{
+			{SDKMethod: "pkg.FooClient.Get", Reason: "api path is not found"},
+			diag,
+		},
+		diags.Items())
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	ResourceChangeAdded    = "added"
+	ResourceChangeRemoved  = "removed"
+	ResourceChangeModified = "modified"
+)
+
+// ResourceDiff is the difference of the API operations of a Terraform resource between two aztfo reports.
+type ResourceDiff struct {
+	Id ResourceId `json:"id"`
+	// Change is one of "added", "removed" and "modified".
+	Change string     `json:"change"`
+	Verbs  []VerbDiff `json:"verbs"`
+}
+
+// VerbDiff is the difference of the API operations of a Terraform resource verb (e.g. "create").
+type VerbDiff struct {
+	Verb           string            `json:"verb"`
+	Added          APIOperations     `json:"added,omitempty"`
+	Removed        APIOperations     `json:"removed,omitempty"`
+	VersionChanged []OperationChange `json:"version_changed,omitempty"`
+	// DetailsChanged are the API operations whose request details (e.g. the headers) are changed, while the API version
+	// remains the same.
+	DetailsChanged []OperationChange `json:"details_changed,omitempty"`
+}
+
+// OperationChange is an API operation that is changed, while the operation kind and path remain the same.
+type OperationChange struct {
+	Old APIOperation `json:"old"`
+	New APIOperation `json:"new"`
+}
+
+// empty tells whether there is no difference.
+func (vd VerbDiff) empty() bool {
+	return len(vd.Added) == 0 && len(vd.Removed) == 0 && len(vd.VersionChanged) == 0 && len(vd.DetailsChanged) == 0
+}
+
+// diffResults compares the old and new results, and returns the differences of the resources that have changed.
+func diffResults(oldResults, newResults Results) []ResourceDiff {
+	oldMap := map[ResourceId]Result{}
+	for _, res := range oldResults {
+		oldMap[res.Id] = res
+	}
+	newMap := map[ResourceId]Result{}
+	for _, res := range newResults {
+		newMap[res.Id] = res
+	}
+
+	var ids []ResourceId
+	for id := range oldMap {
+		ids = append(ids, id)
+	}
+	for id := range newMap {
+		if _, ok := oldMap[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	var diffs []ResourceDiff
+	for _, id := range ids {
+		oldRes, inOld := oldMap[id]
+		newRes, inNew := newMap[id]
+		change := ResourceChangeModified
+		switch {
+		case !inOld:
+			change = ResourceChangeAdded
+		case !inNew:
+			change = ResourceChangeRemoved
+		}
+
+		var verbs []VerbDiff
+		oldVOs, newVOs := oldRes.VerbOperations(), newRes.VerbOperations()
+		for i := range oldVOs {
+			vd := diffOperations(oldVOs[i].Operations, newVOs[i].Operations)
+			if vd.empty() {
+				continue
+			}
+			vd.Verb = oldVOs[i].Verb
+			verbs = append(verbs, vd)
+		}
+		if len(verbs) == 0 && change == ResourceChangeModified {
+			continue
+		}
+		diffs = append(diffs, ResourceDiff{Id: id, Change: change, Verbs: verbs})
+	}
+	return diffs
+}
+
+// diffOperations compares two sets of API operations. The operations that only differ in the ARMPath casing are regarded as
+// the same (see APIOperation.key). The operations of the same kind and path are paired as:
+//
+//   - The details changes, if they have the same API version (and LRO-ness), while differ in the request details, e.g. the
+//     headers and the expected status codes
+//   - The version changes, if they differ in the API version (and the LRO-ness that comes with it)
+//
+// The remaining ones are regarded as additions or removals.
+func diffOperations(oldOps, newOps APIOperations) VerbDiff {
+	type opKey struct {
+		kind     OperationKind
+		path     string
+		plane    string
+		endpoint string
+	}
+	keyOf := func(op APIOperation) opKey {
+		return opKey{kind: op.Kind, path: op.Path, plane: op.Plane, endpoint: op.Endpoint}
+	}
+	var keys []opKey
+	oldGroups := map[opKey]APIOperations{}
+	newGroups := map[opKey]APIOperations{}
+	for _, op := range oldOps {
+		k := keyOf(op)
+		if _, ok := oldGroups[k]; !ok {
+			keys = append(keys, k)
+		}
+		oldGroups[k] = append(oldGroups[k], op)
+	}
+	for _, op := range newOps {
+		k := keyOf(op)
+		if _, ok := oldGroups[k]; !ok {
+			if _, ok := newGroups[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		newGroups[k] = append(newGroups[k], op)
+	}
+
+	var vd VerbDiff
+	for _, k := range keys {
+		var olds, news APIOperations
+		for _, op := range oldGroups[k] {
+			if !slices.ContainsFunc(newGroups[k], func(o APIOperation) bool { return o.key() == op.key() }) {
+				olds = append(olds, op)
+			}
+		}
+		for _, op := range newGroups[k] {
+			if !slices.ContainsFunc(oldGroups[k], func(o APIOperation) bool { return o.key() == op.key() }) {
+				news = append(news, op)
+			}
+		}
+		sort.Sort(olds)
+		sort.Sort(news)
+
+		// Pair the remaining operations of the same API version (and LRO-ness) as details changes.
+		olds = slices.DeleteFunc(olds, func(old APIOperation) bool {
+			i := slices.IndexFunc(news, func(op APIOperation) bool { return op.Version == old.Version && op.IsLRO == old.IsLRO })
+			if i == -1 {
+				return false
+			}
+			vd.DetailsChanged = append(vd.DetailsChanged, OperationChange{Old: old, New: news[i]})
+			news = slices.Delete(news, i, i+1)
+			return true
+		})
+
+		// Pair the remaining operations (in the version order) as version changes.
+		n := min(len(olds), len(news))
+		for i := range n {
+			vd.VersionChanged = append(vd.VersionChanged, OperationChange{Old: olds[i], New: news[i]})
+		}
+		vd.Removed = append(vd.Removed, olds[n:]...)
+		vd.Added = append(vd.Added, news[n:]...)
+	}
+	sort.Sort(vd.Added)
+	sort.Sort(vd.Removed)
+	for _, changes := range [][]OperationChange{vd.VersionChanged, vd.DetailsChanged} {
+		sort.Slice(changes, func(i, j int) bool {
+			return APIOperations{changes[i].Old, changes[j].Old}.Less(0, 1)
+		})
+	}
+	return vd
+}
+
+// changedDetails returns the names of the request details that differ between the two API operations.
+func changedDetails(x, y APIOperation) []string {
+	var names []string
+	if x.ContentType != y.ContentType {
+		names = append(names, "content_type")
+	}
+	if x.QueryParameters != y.QueryParameters {
+		names = append(names, "query_parameters")
+	}
+	if x.Headers != y.Headers {
+		names = append(names, "headers")
+	}
+	if x.ExpectedStatusCodes != y.ExpectedStatusCodes {
+		names = append(names, "expected_status_codes")
+	}
+	return names
+}
+
+// writeDiffText writes the differences in a human readable form.
+func writeDiffText(w io.Writer, diffs []ResourceDiff) {
+	lro := func(op APIOperation) string {
+		if op.IsLRO {
+			return " (LRO)"
+		}
+		return ""
+	}
+	for _, d := range diffs {
+		fmt.Fprintf(w, "%s (%s)\n", d.Id, d.Change)
+		for _, vd := range d.Verbs {
+			fmt.Fprintf(w, "  %s:\n", vd.Verb)
+			for _, op := range vd.Added {
+				fmt.Fprintf(w, "    + %s %s %s%s\n", op.Kind, op.Path, op.Version, lro(op))
+			}
+			for _, op := range vd.Removed {
+				fmt.Fprintf(w, "    - %s %s %s%s\n", op.Kind, op.Path, op.Version, lro(op))
+			}
+			for _, vc := range vd.VersionChanged {
+				fmt.Fprintf(w, "    ~ %s %s %s%s -> %s%s\n", vc.Old.Kind, vc.Old.Path, vc.Old.Version, lro(vc.Old), vc.New.Version, lro(vc.New))
+			}
+			for _, dc := range vd.DetailsChanged {
+				fmt.Fprintf(w, "    * %s %s %s%s (%s)\n", dc.Old.Kind, dc.Old.Path, dc.Old.Version, lro(dc.Old), strings.Join(changedDetails(dc.Old, dc.New), ", "))
+			}
+		}
+	}
+}
+
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	flagJSON := fs.Bool("json", false, "Output the differences in JSON, instead of the human readable text")
+	fs.Usage = func() {
+		fmt.Println(`Usage: aztfo diff [options] <old report> <new report>
+
+Reports the differences of the API operations between two aztfo reports, e.g. from two provider releases.
+
+Arguments:
+  - old report
+	The old aztfo output file.
+  - new report
+	The new aztfo output file.
+
+Options:`)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("expect exactly two arguments, got=%d", fs.NArg())
+	}
+
+	oldResults, err := readResults(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	newResults, err := readResults(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diffs := diffResults(oldResults, newResults)
+	if !*flagJSON {
+		writeDiffText(os.Stdout, diffs)
+		return nil
+	}
+	if diffs == nil {
+		diffs = []ResourceDiff{}
+	}
+	b, err := json.MarshalIndent(diffs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal the output: %v", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
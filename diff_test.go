@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffResults(t *testing.T) {
+	t.Parallel()
+	var (
+		opGetV1  = APIOperation{Kind: OperationKindGet, Version: "2024-01-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"}
+		opGetV2  = APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"}
+		opPut    = APIOperation{Kind: OperationKindPut, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}", IsLRO: true}
+		opPost   = APIOperation{Kind: OperationKindPost, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/LISTKEYS"}
+		opDelete = APIOperation{Kind: OperationKindDelete, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"}
+		opBarGet = APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/BARS/{}"}
+		// The same operation as opBarGet, except the request details.
+		opBarGetExpand = APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/BARS/{}", QueryParameters: "$expand", ExpectedStatusCodes: "200"}
+	)
+	oldResults := Results{
+		{
+			Id:     ResourceId{Name: "azurerm_foo"},
+			Create: APIOperations{opGetV1, opPut},
+			Read:   APIOperations{opGetV1},
+			Delete: APIOperations{opDelete, opPost},
+		},
+		{
+			Id:   ResourceId{Name: "azurerm_bar", Kind: ResourceKindDataSource},
+			Read: APIOperations{opBarGet},
+		},
+		{
+			Id:   ResourceId{Name: "azurerm_baz"},
+			Read: APIOperations{opBarGet},
+		},
+	}
+	newResults := Results{
+		{
+			Id:     ResourceId{Name: "azurerm_foo"},
+			Create: APIOperations{opGetV2, opPut, opPost},
+			Read:   APIOperations{opGetV2},
+			Delete: APIOperations{opDelete},
+		},
+		{
+			Id:   ResourceId{Name: "azurerm_bar"},
+			Read: APIOperations{opBarGet},
+		},
+		{
+			Id:   ResourceId{Name: "azurerm_baz"},
+			Read: APIOperations{opBarGetExpand},
+		},
+	}
+
+	diffs := diffResults(oldResults, newResults)
+	require.Equal(t,
+		[]ResourceDiff{
+			{
+				Id:     ResourceId{Name: "azurerm_bar"},
+				Change: ResourceChangeAdded,
+				Verbs:  []VerbDiff{{Verb: "read", Added: APIOperations{opBarGet}}},
+			},
+			{
+				Id:     ResourceId{Name: "azurerm_bar", Kind: ResourceKindDataSource},
+				Change: ResourceChangeRemoved,
+				Verbs:  []VerbDiff{{Verb: "read", Removed: APIOperations{opBarGet}}},
+			},
+			{
+				Id:     ResourceId{Name: "azurerm_baz"},
+				Change: ResourceChangeModified,
+				Verbs:  []VerbDiff{{Verb: "read", DetailsChanged: []OperationChange{{Old: opBarGet, New: opBarGetExpand}}}},
+			},
+			{
+				Id:     ResourceId{Name: "azurerm_foo"},
+				Change: ResourceChangeModified,
+				Verbs: []VerbDiff{
+					{Verb: "create", Added: APIOperations{opPost}, VersionChanged: []OperationChange{{Old: opGetV1, New: opGetV2}}},
+					{Verb: "read", VersionChanged: []OperationChange{{Old: opGetV1, New: opGetV2}}},
+					{Verb: "delete", Removed: APIOperations{opPost}},
+				},
+			},
+		},
+		diffs)
+
+	var buf bytes.Buffer
+	writeDiffText(&buf, diffs[2:])
+	require.Equal(t, `azurerm_baz (modified)
+  read:
+    * GET /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/BARS/{} 2025-04-01 (query_parameters, expected_status_codes)
+azurerm_foo (modified)
+  create:
+    + POST /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/LISTKEYS 2025-04-01
+    ~ GET /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{} 2024-01-01 -> 2025-04-01
+  read:
+    ~ GET /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{} 2024-01-01 -> 2025-04-01
+  delete:
+    - POST /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/LISTKEYS 2025-04-01
+`, buf.String())
+}
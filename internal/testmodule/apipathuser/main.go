@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+)
+
+const providerPath = "/providers/Microsoft.Foo"
+
+type FooId struct {
+	SubscriptionId string
+	FooName        string
+}
+
+func NewFooID(subscriptionId, fooName string) FooId {
+	return FooId{SubscriptionId: subscriptionId, FooName: fooName}
+}
+
+func (id FooId) ID() string {
+	fmtString := "/subscriptions/%s/providers/Microsoft.Foo/foos/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.FooName)
+}
+
+func barPath(name string) string {
+	return providerPath + "/bars/" + name
+}
+
+func literal() string {
+	return "/providers/Microsoft.Foo/operations"
+}
+
+func concat(name string) string {
+	return providerPath + "/bars/" + name
+}
+
+func constructedID() string {
+	return NewFooID("sub", "foo").ID()
+}
+
+func sprintf(id FooId, name string) string {
+	return fmt.Sprintf("%s/bazs/%s", id.ID(), name)
+}
+
+func helper() string {
+	return barPath("bar") + "/start"
+}
+
+func unresolved(name string) string {
+	return url.PathEscape(name) + "/bars"
+}
+
+func branch(id FooId) string {
+	p := id.ID()
+	if os.Getenv("FOO") != "" {
+		p += "/bars"
+	}
+	return p
+}
+
+func branchSegment(id FooId) string {
+	kind := "bars"
+	if os.Getenv("FOO") != "" {
+		kind = "bazs"
+	}
+	return id.ID() + "/" + kind + "/default"
+}
+
+func main() {}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/azuresdk"
+)
+
+func main() {
+	ctx := context.TODO()
+	c := azuresdk.GenericClient{}
+	c.GetByID(ctx, "", "2021-01-01")
+	getByID(ctx, c, "2022-02-02")
+	getByID(ctx, c, apiVersion())
+}
+
+func getByID(ctx context.Context, c azuresdk.GenericClient, version string) {
+	c.GetByID(ctx, "", version)
+}
+
+func apiVersion() string {
+	return "2023-03-03"
+}
+
+// other is not reachable from main, its API version shall not be reported for main.
+func other() {
+	getByID(context.TODO(), azuresdk.GenericClient{}, "2099-01-01")
+}
@@ -0,0 +1,64 @@
+package azuresdk
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// GenericClient mimics the track1 resources.Client, whose API version is specified by the caller.
+type GenericClient struct {
+	BaseURI string
+}
+
+func (client GenericClient) GetByID(ctx context.Context, resourceID string, APIVersion string) (result GenericResource, err error) {
+	req, err := client.GetByIDPreparer(ctx, resourceID, APIVersion)
+	if err != nil {
+		return
+	}
+
+	resp, err := client.GetByIDSender(req)
+	if err != nil {
+		return
+	}
+
+	result, err = client.GetByIDResponder(resp)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (client GenericClient) GetByIDPreparer(ctx context.Context, resourceID string, APIVersion string) (*http.Request, error) {
+	pathParameters := map[string]interface{}{
+		"resourceId": resourceID,
+	}
+
+	queryParameters := map[string]interface{}{
+		"api-version": APIVersion,
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/{resourceId}", pathParameters),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client GenericClient) GetByIDSender(req *http.Request) (*http.Response, error) {
+	return nil, nil
+}
+
+func (client GenericClient) GetByIDResponder(resp *http.Response) (result GenericResource, err error) {
+	err = autorest.Respond(
+		resp,
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+	return
+}
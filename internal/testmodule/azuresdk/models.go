@@ -15,3 +15,7 @@ type FooCreateOrUpdateFuture struct {
 type Foo struct {
 	autorest.Response `json:"-"`
 }
+
+type GenericResource struct {
+	autorest.Response `json:"-"`
+}
@@ -0,0 +1,100 @@
+package azuresdktrack2
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/magodo/aztfo/internal/testmodule/azuresdktrack2/runtime"
+)
+
+type FoosClient struct {
+	endpoint       string
+	subscriptionID string
+}
+
+func (client *FoosClient) Get(ctx context.Context, resourceGroupName string, fooName string, options *FoosClientGetOptions) (FoosClientGetResponse, error) {
+	req, err := client.getCreateRequest(ctx, resourceGroupName, fooName, options)
+	if err != nil {
+		return FoosClientGetResponse{}, err
+	}
+	_ = req
+	return FoosClientGetResponse{}, nil
+}
+
+func (client *FoosClient) getCreateRequest(ctx context.Context, resourceGroupName string, fooName string, _ *FoosClientGetOptions) (*runtime.Request, error) {
+	urlPath := "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Foo/foos/{fooName}"
+	urlPath = strings.ReplaceAll(urlPath, "{subscriptionId}", runtime.PathEscape(client.subscriptionID))
+	urlPath = strings.ReplaceAll(urlPath, "{resourceGroupName}", runtime.PathEscape(resourceGroupName))
+	urlPath = strings.ReplaceAll(urlPath, "{fooName}", runtime.PathEscape(fooName))
+	req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.endpoint, urlPath))
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2025-04-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, nil
+}
+
+func (client *FoosClient) BeginCreateOrUpdate(ctx context.Context, resourceGroupName string, fooName string, parameters Foo, options *FoosClientBeginCreateOrUpdateOptions) (*runtime.Poller[FoosClientCreateOrUpdateResponse], error) {
+	resp, err := client.createOrUpdate(ctx, resourceGroupName, fooName, parameters, options)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.NewPoller(resp, &runtime.NewPollerOptions[FoosClientCreateOrUpdateResponse]{
+		FinalStateVia: runtime.FinalStateViaAzureAsyncOp,
+	})
+}
+
+func (client *FoosClient) createOrUpdate(ctx context.Context, resourceGroupName string, fooName string, parameters Foo, options *FoosClientBeginCreateOrUpdateOptions) (*http.Response, error) {
+	req, err := client.createOrUpdateCreateRequest(ctx, resourceGroupName, fooName, parameters, options)
+	if err != nil {
+		return nil, err
+	}
+	_ = req
+	return nil, nil
+}
+
+func (client *FoosClient) createOrUpdateCreateRequest(ctx context.Context, resourceGroupName string, fooName string, _ Foo, _ *FoosClientBeginCreateOrUpdateOptions) (*runtime.Request, error) {
+	urlPath := "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Foo/foos/{fooName}"
+	urlPath = strings.ReplaceAll(urlPath, "{subscriptionId}", runtime.PathEscape(client.subscriptionID))
+	urlPath = strings.ReplaceAll(urlPath, "{resourceGroupName}", runtime.PathEscape(resourceGroupName))
+	urlPath = strings.ReplaceAll(urlPath, "{fooName}", runtime.PathEscape(fooName))
+	req, err := runtime.NewRequest(ctx, http.MethodPut, runtime.JoinPaths(client.endpoint, urlPath))
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2025-04-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, nil
+}
+
+func (client *FoosClient) NewListByResourceGroupPager(resourceGroupName string, options *FoosClientListByResourceGroupOptions) *runtime.Pager[FoosClientListByResourceGroupResponse] {
+	return runtime.NewPager(func(ctx context.Context, page *FoosClientListByResourceGroupResponse) (FoosClientListByResourceGroupResponse, error) {
+		req, err := client.listByResourceGroupCreateRequest(ctx, resourceGroupName, options)
+		if err != nil {
+			return FoosClientListByResourceGroupResponse{}, err
+		}
+		_ = req
+		return FoosClientListByResourceGroupResponse{}, nil
+	})
+}
+
+func (client *FoosClient) listByResourceGroupCreateRequest(ctx context.Context, resourceGroupName string, _ *FoosClientListByResourceGroupOptions) (*runtime.Request, error) {
+	urlPath := "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Foo/foos"
+	urlPath = strings.ReplaceAll(urlPath, "{subscriptionId}", runtime.PathEscape(client.subscriptionID))
+	urlPath = strings.ReplaceAll(urlPath, "{resourceGroupName}", runtime.PathEscape(resourceGroupName))
+	req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.endpoint, urlPath))
+	if err != nil {
+		return nil, err
+	}
+	reqQP := req.Raw().URL.Query()
+	reqQP.Set("api-version", "2025-04-01")
+	req.Raw().URL.RawQuery = reqQP.Encode()
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	return req, nil
+}
@@ -0,0 +1,31 @@
+package azuresdktrack2
+
+type Foo struct {
+	ID   *string
+	Name *string
+}
+
+type FooListResult struct {
+	Value    []*Foo
+	NextLink *string
+}
+
+type FoosClientGetOptions struct{}
+
+type FoosClientBeginCreateOrUpdateOptions struct {
+	ResumeToken string
+}
+
+type FoosClientListByResourceGroupOptions struct{}
+
+type FoosClientGetResponse struct {
+	Foo
+}
+
+type FoosClientCreateOrUpdateResponse struct {
+	Foo
+}
+
+type FoosClientListByResourceGroupResponse struct {
+	FooListResult
+}
@@ -0,0 +1,70 @@
+// Package runtime is a minimal stand-in of the "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime" package.
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type Request struct {
+	req *http.Request
+}
+
+func (r *Request) Raw() *http.Request {
+	return r.req
+}
+
+func NewRequest(ctx context.Context, httpMethod string, endpoint string) (*Request, error) {
+	req, err := http.NewRequestWithContext(ctx, httpMethod, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Request{req: req}, nil
+}
+
+func JoinPaths(root string, paths ...string) string {
+	return root + strings.Join(paths, "")
+}
+
+type Poller[T any] struct {
+	result T
+}
+
+type FinalStateVia string
+
+const (
+	FinalStateViaAzureAsyncOp FinalStateVia = "azure-async-operation"
+	FinalStateViaLocation     FinalStateVia = "location"
+	FinalStateViaOriginalURI  FinalStateVia = "original-uri"
+	FinalStateViaOpLocation   FinalStateVia = "operation-location"
+)
+
+type NewPollerOptions[T any] struct {
+	FinalStateVia FinalStateVia
+}
+
+func NewPoller[T any](resp *http.Response, options *NewPollerOptions[T]) (*Poller[T], error) {
+	return &Poller[T]{}, nil
+}
+
+func (p *Poller[T]) PollUntilDone(ctx context.Context) (T, error) {
+	return p.result, nil
+}
+
+type Pager[T any] struct {
+	fetcher func(context.Context, *T) (T, error)
+}
+
+func NewPager[T any](fetcher func(context.Context, *T) (T, error)) *Pager[T] {
+	return &Pager[T]{fetcher: fetcher}
+}
+
+func (p *Pager[T]) NextPage(ctx context.Context) (T, error) {
+	return p.fetcher(ctx, nil)
+}
+
+func PathEscape(s string) string {
+	return url.PathEscape(s)
+}
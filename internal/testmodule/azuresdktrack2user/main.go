@@ -0,0 +1,15 @@
+package main
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/azuresdktrack2"
+)
+
+func main() {
+	ctx := context.TODO()
+	c := &azuresdktrack2.FoosClient{}
+	c.BeginCreateOrUpdate(ctx, "", "", azuresdktrack2.Foo{}, nil)
+	c.Get(ctx, "", "", nil)
+	c.NewListByResourceGroupPager("", nil)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/azuresdk"
+)
+
+type fooGetter interface {
+	Get(ctx context.Context, resourceGroupName string, fooName string) (azuresdk.Foo, error)
+}
+
+func main() {
+	ctx := context.TODO()
+	c := azuresdk.FooClient{}
+
+	// Static call
+	c.CreateOrUpdate(ctx, "", "", azuresdk.Foo{})
+
+	// Interface method call
+	var getter fooGetter = c
+	getter.Get(ctx, "", "")
+}
+
+// get is not called, it only makes the FooClient.Get a used SDK method.
+func get(c azuresdk.FooClient) {
+	c.Get(context.TODO(), "", "")
+}
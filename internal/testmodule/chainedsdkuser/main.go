@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/azuresdk"
+)
+
+type Client struct {
+	Foo *FooClients
+}
+
+type FooClients struct {
+	FooClient azuresdk.FooClient
+}
+
+func main() {
+	ctx := context.TODO()
+	var meta interface{} = &Client{Foo: &FooClients{}}
+
+	// The SDK method is called through the chained selectors.
+	meta.(*Client).Foo.FooClient.CreateOrUpdate(ctx, "", "", azuresdk.Foo{})
+
+	// The SDK method is passed as a callback.
+	get(ctx, meta.(*Client).Foo.FooClient.Get)
+}
+
+func get(ctx context.Context, f func(ctx context.Context, resourceGroupName string, fooName string) (azuresdk.Foo, error)) {
+	f(ctx, "", "")
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/magodo/aztfo/internal/testmodule/hashicorpsdk"
+)
+
+// fooPoller is a custom poller, which polls the state of the foo.
+type fooPoller struct {
+	client hashicorpsdk.FooClientNative
+	id     hashicorpsdk.FooId
+}
+
+func (p fooPoller) Poll(ctx context.Context) (*pollers.PollResult, error) {
+	client := p.client
+	if _, err := client.Get(ctx, p.id); err != nil {
+		return nil, err
+	}
+	return &pollers.PollResult{Status: pollers.PollingStatusSucceeded}, nil
+}
+
+func main() {
+	ctx := context.TODO()
+	c := hashicorpsdk.FooClientNative{}
+	id := hashicorpsdk.FooId{}
+	if err := c.CreateThenPoll(ctx, id, hashicorpsdk.Foo{}); err != nil {
+		return
+	}
+
+	poller := pollers.NewPoller(fooPoller{client: c, id: id}, time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+	poller.PollUntilDone(ctx)
+}
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/customsdkuser/sdk"
+)
+
+func main() {
+	ctx := context.TODO()
+	sdk.BarClient{}.Get(ctx, sdk.BarId{})
+	sdk.DeleteBar(ctx, nil, "")
+	sdk.BazClient{}.Get(ctx, "", "", "")
+}
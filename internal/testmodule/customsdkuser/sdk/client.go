@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
+)
+
+const defaultApiVersion = "2025-05-01"
+
+type BarId struct {
+	SubscriptionId string
+	BarName        string
+}
+
+func (id BarId) ID() string {
+	fmtString := "/subscriptions/%s/providers/Microsoft.Foo/bars/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.BarName)
+}
+
+type BarClient struct {
+	Client *resourcemanager.Client
+}
+
+type GetOperationResponse struct {
+	HttpResponse *http.Response
+}
+
+func (c BarClient) Get(ctx context.Context, id BarId) (result GetOperationResponse, err error) {
+	req, err := c.Client.NewRequest(ctx, c.getOptions(id))
+	if err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.HttpResponse = resp.Response
+	}
+	return
+}
+
+// getOptions builds the request options out of the SDK method.
+func (c BarClient) getOptions(id BarId) client.RequestOptions {
+	return client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       id.ID(),
+	}
+}
+
+// DeleteBar is a plain function, instead of a method of a client.
+func DeleteBar(ctx context.Context, c *resourcemanager.Client, name string) error {
+	opts := client.RequestOptions{
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodDelete,
+		Path:       fmt.Sprintf("/providers/Microsoft.Foo/bars/%s", name),
+	}
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return err
+	}
+	_, err = req.Execute(ctx)
+	return err
+}
+
+type BazClient struct {
+	BaseURI string
+}
+
+func (client BazClient) Get(ctx context.Context, name string, expand string, ifNoneMatch string) (*http.Response, error) {
+	req, err := client.GetPreparer(ctx, name, expand, ifNoneMatch)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetResponder(resp)
+}
+
+func (client BazClient) GetPreparer(ctx context.Context, name string, expand string, ifNoneMatch string) (*http.Request, error) {
+	pathParameters := map[string]interface{}{
+		"name": autorest.Encode("path", name),
+	}
+
+	const APIVersion = "2025-06-01"
+	queryParameters := map[string]interface{}{
+		"api-version": APIVersion,
+	}
+	if expand != "" {
+		queryParameters["$expand"] = autorest.Encode("query", expand)
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/providers/Microsoft.Foo/bazs/{name}", pathParameters),
+		autorest.WithQueryParameters(queryParameters))
+	if ifNoneMatch != "" {
+		preparer = autorest.DecoratePreparer(preparer, autorest.WithHeader("If-None-Match", autorest.String(ifNoneMatch)))
+	}
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client BazClient) GetResponder(resp *http.Response) (*http.Response, error) {
+	err := autorest.Respond(
+		resp,
+		azure.WithErrorUnlessStatusCode(http.StatusOK, http.StatusNotModified),
+		autorest.ByClosing())
+	return resp, err
+}
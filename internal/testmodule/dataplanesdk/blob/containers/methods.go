@@ -0,0 +1,73 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+type CreateResponse struct {
+	HttpResponse *http.Response
+}
+
+// Create creates a new container within the Storage Account
+func (c Client) Create(ctx context.Context, containerName string) (resp CreateResponse, err error) {
+	if containerName == "" {
+		return resp, fmt.Errorf("`containerName` cannot be an empty string")
+	}
+	if strings.ToLower(containerName) != containerName {
+		return resp, fmt.Errorf("`containerName` must be a lower-cased string")
+	}
+
+	opts := client.RequestOptions{
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+		},
+		HttpMethod: http.MethodPut,
+		Path:       fmt.Sprintf("/%s", containerName),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		err = fmt.Errorf("building request: %+v", err)
+		return
+	}
+
+	var resp2 *client.Response
+	resp2, err = req.Execute(ctx)
+	if resp2 != nil {
+		resp.HttpResponse = resp2.Response
+	}
+	return
+}
+
+type GetPropertiesResponse struct {
+	HttpResponse *http.Response
+}
+
+// GetProperties returns the properties for this Container without a Lease
+func (c Client) GetProperties(ctx context.Context, containerName string) (resp GetPropertiesResponse, err error) {
+	opts := client.RequestOptions{
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       fmt.Sprintf("/%s", containerName),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		err = fmt.Errorf("building request: %+v", err)
+		return
+	}
+
+	var resp2 *client.Response
+	resp2, err = req.Execute(ctx)
+	if resp2 != nil {
+		resp.HttpResponse = resp2.Response
+	}
+	return
+}
@@ -0,0 +1,6 @@
+package containers
+
+// APIVersion is the version of the API used for all Storage API Operations
+const apiVersion = "2023-11-03"
+
+const componentName = "blob/containers"
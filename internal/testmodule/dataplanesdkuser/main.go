@@ -0,0 +1,19 @@
+package main
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/dataplanesdk/blob/containers"
+	"github.com/magodo/aztfo/internal/testmodule/kermitsdk/keyvault"
+)
+
+func main() {
+	ctx := context.TODO()
+
+	cc := containers.Client{}
+	cc.Create(ctx, "foo")
+	cc.GetProperties(ctx, "foo")
+
+	kc := keyvault.BaseClient{}
+	kc.GetSecret(ctx, "https://foo.vault.azure.net", "foo", "")
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/hashicorpsdk"
+)
+
+func main() {
+	create(context.TODO())
+}
+
+func create(ctx context.Context) {
+	c := hashicorpsdk.FooClientNative{}
+	c.CreateThenPoll(ctx, hashicorpsdk.FooId{}, hashicorpsdk.Foo{})
+}
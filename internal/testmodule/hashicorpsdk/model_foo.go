@@ -25,3 +25,8 @@ type NativeOperationResponse struct {
 	HttpResponse *http.Response
 	Model        *Foo
 }
+
+type NativeGetOperationResponse struct {
+	HttpResponse *http.Response
+	Model        *Foo
+}
@@ -13,4 +13,6 @@ func main() {
 
 	// Calling the non-LRO version won't cause a duplicated record
 	c.Create(ctx, hashicorpsdk.FooId{}, hashicorpsdk.Foo{})
+
+	c.List(ctx, hashicorpsdk.FooId{}, hashicorpsdk.ListOperationOptions{})
 }
@@ -0,0 +1,70 @@
+package keyvault
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+type BaseClient struct {
+	autorest.Client
+}
+
+type SecretBundle struct {
+	autorest.Response `json:"-"`
+	Value             *string `json:"value,omitempty"`
+}
+
+func (client BaseClient) GetSecret(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string) (result SecretBundle, err error) {
+	req, err := client.GetSecretPreparer(ctx, vaultBaseURL, secretName, secretVersion)
+	if err != nil {
+		return
+	}
+
+	resp, err := client.GetSecretSender(req)
+	if err != nil {
+		return
+	}
+
+	result, err = client.GetSecretResponder(resp)
+	return
+}
+
+func (client BaseClient) GetSecretPreparer(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string) (*http.Request, error) {
+	urlParameters := map[string]interface{}{
+		"vaultBaseUrl": vaultBaseURL,
+	}
+
+	pathParameters := map[string]interface{}{
+		"secret-name":    autorest.Encode("path", secretName),
+		"secret-version": autorest.Encode("path", secretVersion),
+	}
+
+	const APIVersion = "7.4"
+	queryParameters := map[string]interface{}{
+		"api-version": APIVersion,
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithCustomBaseURL("{vaultBaseUrl}", urlParameters),
+		autorest.WithPathParameters("/secrets/{secret-name}/{secret-version}", pathParameters),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client BaseClient) GetSecretSender(req *http.Request) (*http.Response, error) {
+	return nil, nil
+}
+
+func (client BaseClient) GetSecretResponder(resp *http.Response) (result SecretBundle, err error) {
+	err = autorest.Respond(
+		resp,
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+	return
+}
@@ -0,0 +1,23 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	sdkEnv "github.com/hashicorp/go-azure-sdk/sdk/environments"
+)
+
+type ApplicationClient struct {
+	Client *msgraph.Client
+}
+
+func NewApplicationClientWithBaseURI(sdkApi sdkEnv.Api) (*ApplicationClient, error) {
+	client, err := msgraph.NewMsGraphClient(sdkApi, "application", msgraph.VersionOnePointZero)
+	if err != nil {
+		return nil, fmt.Errorf("instantiating ApplicationClient: %+v", err)
+	}
+
+	return &ApplicationClient{
+		Client: client,
+	}, nil
+}
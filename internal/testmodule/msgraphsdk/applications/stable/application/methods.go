@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/magodo/aztfo/internal/testmodule/msgraphsdk/common-types/stable"
+)
+
+type OperationResponse struct {
+	HttpResponse *http.Response
+}
+
+func (c ApplicationClient) GetApplication(ctx context.Context, id stable.ApplicationId) (result OperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       id.ID(),
+	}
+	return c.execute(ctx, opts)
+}
+
+func (c ApplicationClient) UpdateApplication(ctx context.Context, id stable.ApplicationId) (result OperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+		},
+		HttpMethod: http.MethodPatch,
+		Path:       id.ID(),
+	}
+	return c.execute(ctx, opts)
+}
+
+func (c ApplicationClient) AddOwnerRef(ctx context.Context, id stable.ApplicationId) (result OperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+		},
+		HttpMethod: http.MethodPost,
+		Path:       fmt.Sprintf("%s/owners/$ref", id.ID()),
+	}
+	return c.execute(ctx, opts)
+}
+
+func (c ApplicationClient) ListApplications(ctx context.Context) (result OperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Path:       "/applications",
+	}
+	return c.execute(ctx, opts)
+}
+
+func (c ApplicationClient) ListApplicationsComplete(ctx context.Context) (result OperationResponse, err error) {
+	return c.ListApplications(ctx)
+}
+
+func (c ApplicationClient) execute(ctx context.Context, opts client.RequestOptions) (result OperationResponse, err error) {
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.HttpResponse = resp.Response
+	}
+	return
+}
@@ -0,0 +1,18 @@
+package beta
+
+import "fmt"
+
+type GroupId struct {
+	GroupId string
+}
+
+func NewGroupID(groupId string) GroupId {
+	return GroupId{
+		GroupId: groupId,
+	}
+}
+
+func (id GroupId) ID() string {
+	fmtString := "/groups/%s"
+	return fmt.Sprintf(fmtString, id.GroupId)
+}
@@ -0,0 +1,18 @@
+package stable
+
+import "fmt"
+
+type ApplicationId struct {
+	ApplicationId string
+}
+
+func NewApplicationID(applicationId string) ApplicationId {
+	return ApplicationId{
+		ApplicationId: applicationId,
+	}
+}
+
+func (id ApplicationId) ID() string {
+	fmtString := "/applications/%s"
+	return fmt.Sprintf(fmtString, id.ApplicationId)
+}
@@ -0,0 +1,57 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/msgraph"
+	sdkEnv "github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/magodo/aztfo/internal/testmodule/msgraphsdk/common-types/beta"
+)
+
+// stableVersion is the API version of the stable counterpart, which is not used to construct the client.
+const stableVersion = msgraph.VersionOnePointZero
+
+type GroupClient struct {
+	Client *msgraph.Client
+}
+
+func NewGroupClientWithBaseURI(sdkApi sdkEnv.Api) (*GroupClient, error) {
+	client, err := msgraph.NewMsGraphClient(sdkApi, "group", msgraph.VersionBeta)
+	if err != nil {
+		return nil, fmt.Errorf("instantiating GroupClient: %+v", err)
+	}
+
+	return &GroupClient{
+		Client: client,
+	}, nil
+}
+
+type DeleteGroupOperationResponse struct {
+	HttpResponse *http.Response
+}
+
+func (c GroupClient) DeleteGroup(ctx context.Context, id beta.GroupId) (result DeleteGroupOperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+		},
+		HttpMethod: http.MethodDelete,
+		Path:       id.ID(),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.HttpResponse = resp.Response
+	}
+	return
+}
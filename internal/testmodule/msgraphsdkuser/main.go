@@ -0,0 +1,23 @@
+package main
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/msgraphsdk/applications/stable/application"
+	"github.com/magodo/aztfo/internal/testmodule/msgraphsdk/common-types/beta"
+	"github.com/magodo/aztfo/internal/testmodule/msgraphsdk/common-types/stable"
+	"github.com/magodo/aztfo/internal/testmodule/msgraphsdk/groups/beta/group"
+)
+
+func main() {
+	ctx := context.TODO()
+	ac := application.ApplicationClient{}
+	id := stable.NewApplicationID("00000000-0000-0000-0000-000000000000")
+	ac.GetApplication(ctx, id)
+	ac.UpdateApplication(ctx, id)
+	ac.AddOwnerRef(ctx, id)
+	ac.ListApplicationsComplete(ctx)
+
+	gc := group.GroupClient{}
+	gc.DeleteGroup(ctx, beta.NewGroupID("00000000-0000-0000-0000-000000000000"))
+}
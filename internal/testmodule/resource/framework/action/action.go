@@ -0,0 +1,20 @@
+// Package action is a minimal stand-in of the "github.com/hashicorp/terraform-plugin-framework/action" package.
+package action
+
+import "context"
+
+type Action interface {
+	Metadata(context.Context, MetadataRequest, *MetadataResponse)
+	Invoke(context.Context, InvokeRequest, *InvokeResponse)
+}
+
+type MetadataRequest struct {
+	ProviderTypeName string
+}
+
+type MetadataResponse struct {
+	TypeName string
+}
+
+type InvokeRequest struct{}
+type InvokeResponse struct{}
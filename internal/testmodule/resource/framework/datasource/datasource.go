@@ -0,0 +1,20 @@
+// Package datasource is a minimal stand-in of the "github.com/hashicorp/terraform-plugin-framework/datasource" package.
+package datasource
+
+import "context"
+
+type DataSource interface {
+	Metadata(context.Context, MetadataRequest, *MetadataResponse)
+	Read(context.Context, ReadRequest, *ReadResponse)
+}
+
+type MetadataRequest struct {
+	ProviderTypeName string
+}
+
+type MetadataResponse struct {
+	TypeName string
+}
+
+type ReadRequest struct{}
+type ReadResponse struct{}
@@ -0,0 +1,34 @@
+// Package ephemeral is a minimal stand-in of the "github.com/hashicorp/terraform-plugin-framework/ephemeral" package.
+package ephemeral
+
+import "context"
+
+type EphemeralResource interface {
+	Metadata(context.Context, MetadataRequest, *MetadataResponse)
+	Open(context.Context, OpenRequest, *OpenResponse)
+}
+
+type EphemeralResourceWithRenew interface {
+	EphemeralResource
+	Renew(context.Context, RenewRequest, *RenewResponse)
+}
+
+type EphemeralResourceWithClose interface {
+	EphemeralResource
+	Close(context.Context, CloseRequest, *CloseResponse)
+}
+
+type MetadataRequest struct {
+	ProviderTypeName string
+}
+
+type MetadataResponse struct {
+	TypeName string
+}
+
+type OpenRequest struct{}
+type OpenResponse struct{}
+type RenewRequest struct{}
+type RenewResponse struct{}
+type CloseRequest struct{}
+type CloseResponse struct{}
@@ -0,0 +1,20 @@
+// Package list is a minimal stand-in of the "github.com/hashicorp/terraform-plugin-framework/list" package.
+package list
+
+import "context"
+
+type ListResource interface {
+	Metadata(context.Context, MetadataRequest, *MetadataResponse)
+	List(context.Context, ListRequest, *ListResultsStream)
+}
+
+type MetadataRequest struct {
+	ProviderTypeName string
+}
+
+type MetadataResponse struct {
+	TypeName string
+}
+
+type ListRequest struct{}
+type ListResultsStream struct{}
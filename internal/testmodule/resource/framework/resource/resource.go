@@ -0,0 +1,54 @@
+// Package resource is a minimal stand-in of the "github.com/hashicorp/terraform-plugin-framework/resource" package.
+package resource
+
+import "context"
+
+type Resource interface {
+	Metadata(context.Context, MetadataRequest, *MetadataResponse)
+	Create(context.Context, CreateRequest, *CreateResponse)
+	Read(context.Context, ReadRequest, *ReadResponse)
+	Update(context.Context, UpdateRequest, *UpdateResponse)
+	Delete(context.Context, DeleteRequest, *DeleteResponse)
+}
+
+type ResourceWithImportState interface {
+	Resource
+	ImportState(context.Context, ImportStateRequest, *ImportStateResponse)
+}
+
+type ResourceWithModifyPlan interface {
+	Resource
+	ModifyPlan(context.Context, ModifyPlanRequest, *ModifyPlanResponse)
+}
+
+type ResourceWithUpgradeState interface {
+	Resource
+	UpgradeState(context.Context) map[int64]StateUpgrader
+}
+
+type StateUpgrader struct {
+	StateUpgrader func(context.Context, UpgradeStateRequest, *UpgradeStateResponse)
+}
+
+type MetadataRequest struct {
+	ProviderTypeName string
+}
+
+type MetadataResponse struct {
+	TypeName string
+}
+
+type CreateRequest struct{}
+type CreateResponse struct{}
+type ReadRequest struct{}
+type ReadResponse struct{}
+type UpdateRequest struct{}
+type UpdateResponse struct{}
+type DeleteRequest struct{}
+type DeleteResponse struct{}
+type ImportStateRequest struct{}
+type ImportStateResponse struct{}
+type ModifyPlanRequest struct{}
+type ModifyPlanResponse struct{}
+type UpgradeStateRequest struct{}
+type UpgradeStateResponse struct{}
@@ -1,6 +1,10 @@
 package pluginsdk
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 type (
 	Resource     = schema.Resource
@@ -14,3 +18,47 @@ type (
 	ReadFunc   = schema.ReadFunc
 	UpdateFunc = schema.UpdateFunc
 )
+
+type (
+	ResourceImporter  = schema.ResourceImporter
+	ResourceDiff      = schema.ResourceDiff
+	StateUpgrader     = schema.StateUpgrader
+	CustomizeDiffFunc = schema.CustomizeDiffFunc
+	StateUpgraderFunc = schema.StateUpgradeFunc
+)
+
+type IDValidationFunc func(id string) error
+
+// ImporterValidatingResourceId validates the ID provided at import time is valid.
+func ImporterValidatingResourceId(validateFunc IDValidationFunc) *ResourceImporter {
+	return &ResourceImporter{
+		StateContext: func(ctx context.Context, d *ResourceData, meta interface{}) ([]*ResourceData, error) {
+			if err := validateFunc(d.Id()); err != nil {
+				return nil, err
+			}
+			return []*ResourceData{d}, nil
+		},
+	}
+}
+
+type CustomizeDiffShimFunc = func(ctx context.Context, d *ResourceDiff, meta interface{}) error
+
+func CustomizeDiffShim(diffFunc CustomizeDiffShimFunc) CustomizeDiffFunc {
+	return func(ctx context.Context, d *ResourceDiff, meta interface{}) error {
+		return diffFunc(ctx, d, meta)
+	}
+}
+
+type StateUpgrade interface {
+	Schema() map[string]*schema.Schema
+	UpgradeFunc() StateUpgraderFunc
+}
+
+// StateUpgrades is a wrapper around the Plugin SDK's State Upgraders.
+func StateUpgrades(upgrades map[int]StateUpgrade) []StateUpgrader {
+	var out []StateUpgrader
+	for v, u := range upgrades {
+		out = append(out, StateUpgrader{Version: v, Upgrade: u.UpgradeFunc()})
+	}
+	return out
+}
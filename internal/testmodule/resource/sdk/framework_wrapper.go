@@ -0,0 +1,32 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/datasource"
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/resource"
+)
+
+// FrameworkWrappedResource is the provider's wrapper of the terraform-plugin-framework resources, which are registered
+// as is (instead of their constructors), and get the decoded model and the client metadata from the wrapper.
+type FrameworkWrappedResource interface {
+	ResourceType() string
+	ModelObject() any
+
+	Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse, metadata ResourceMetaData, plan any)
+	Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse, metadata ResourceMetaData, state any)
+	Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse, metadata ResourceMetaData, plan any, state any)
+	Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse, metadata ResourceMetaData, state any)
+}
+
+type FrameworkWrappedResourceWithImport interface {
+	FrameworkWrappedResource
+	ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse, metadata ResourceMetaData)
+}
+
+type FrameworkWrappedDataSource interface {
+	ResourceType() string
+	ModelObject() any
+
+	Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse, metadata ResourceMetaData, state any)
+}
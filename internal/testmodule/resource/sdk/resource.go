@@ -2,6 +2,8 @@ package sdk
 
 import (
 	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/resource/pluginsdk"
 )
 
 type ResourceMetaData struct{}
@@ -28,3 +30,23 @@ type DataSource interface {
 	resourceBase
 	Read() ResourceFunc
 }
+
+type ResourceWithCustomImporter interface {
+	Resource
+	CustomImporter() ResourceRunFunc
+}
+
+type ResourceWithCustomizeDiff interface {
+	Resource
+	CustomizeDiff() ResourceFunc
+}
+
+type StateUpgradeData struct {
+	SchemaVersion int
+	Upgraders     map[int]pluginsdk.StateUpgrade
+}
+
+type ResourceWithStateMigration interface {
+	Resource
+	StateUpgraders() StateUpgradeData
+}
@@ -0,0 +1,15 @@
+package broken
+
+import (
+	"github.com/magodo/aztfo/internal/testmodule/resource/pluginsdk"
+)
+
+type Registration struct{}
+
+// SupportedResources returns the supported Resources supported by this Service
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"untyped_resource":        untypedResource(),
+		"untyped_resource_broken": untypedResourceBroken(),
+	}
+}
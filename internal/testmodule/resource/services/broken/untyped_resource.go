@@ -0,0 +1,24 @@
+package broken
+
+import "github.com/magodo/aztfo/internal/testmodule/resource/pluginsdk"
+
+func untypedResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: untypedResourceRead,
+	}
+}
+
+func untypedResourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	return nil
+}
+
+var funcs = map[string]pluginsdk.ReadFunc{
+	"read": untypedResourceRead,
+}
+
+// untypedResourceBroken has its Read function defined in an unexpected form.
+func untypedResourceBroken() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: funcs["read"],
+	}
+}
@@ -0,0 +1,22 @@
+package empty
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/action"
+)
+
+var _ action.Action = Action{}
+
+type Action struct{}
+
+func NewAction() action.Action {
+	return Action{}
+}
+
+func (a Action) Metadata(_ context.Context, _ action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = "action"
+}
+
+func (a Action) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+}
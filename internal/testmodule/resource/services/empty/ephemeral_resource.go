@@ -0,0 +1,31 @@
+package empty
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/ephemeral"
+)
+
+var (
+	_ ephemeral.EphemeralResourceWithRenew = &EphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose = &EphemeralResource{}
+)
+
+type EphemeralResource struct{}
+
+func NewEphemeralResource() ephemeral.EphemeralResource {
+	return &EphemeralResource{}
+}
+
+func (e *EphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "ephemeral_resource"
+}
+
+func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+}
+
+func (e *EphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+}
+
+func (e *EphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+}
@@ -0,0 +1,22 @@
+package empty
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/datasource"
+)
+
+var _ datasource.DataSource = &FrameworkDataSource{}
+
+type FrameworkDataSource struct{}
+
+func NewFrameworkDataSource() datasource.DataSource {
+	return &FrameworkDataSource{}
+}
+
+func (d *FrameworkDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "framework_datasource"
+}
+
+func (d *FrameworkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+}
@@ -0,0 +1,54 @@
+package empty
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/resource"
+)
+
+var (
+	_ resource.ResourceWithImportState  = &FrameworkResource{}
+	_ resource.ResourceWithModifyPlan   = &FrameworkResource{}
+	_ resource.ResourceWithUpgradeState = &FrameworkResource{}
+)
+
+type FrameworkResource struct{}
+
+func NewFrameworkResource() resource.Resource {
+	return &FrameworkResource{}
+}
+
+func (r *FrameworkResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "framework_resource"
+}
+
+func (r *FrameworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+}
+
+func (r *FrameworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (r *FrameworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+}
+
+func (r *FrameworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *FrameworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+}
+
+func (r *FrameworkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+}
+
+func (r *FrameworkResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: frameworkResourceUpgradeV0},
+		1: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			},
+		},
+	}
+}
+
+func frameworkResourceUpgradeV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+}
@@ -0,0 +1,22 @@
+package empty
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/list"
+)
+
+var _ list.ListResource = &ListResource{}
+
+type ListResource struct{}
+
+func NewListResource() list.ListResource {
+	return &ListResource{}
+}
+
+func (r *ListResource) Metadata(_ context.Context, _ list.MetadataRequest, resp *list.MetadataResponse) {
+	resp.TypeName = "typed_resource"
+}
+
+func (r *ListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+}
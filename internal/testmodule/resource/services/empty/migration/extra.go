@@ -0,0 +1,22 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/magodo/aztfo/internal/testmodule/resource/pluginsdk"
+)
+
+var _ pluginsdk.StateUpgrade = ExtraV0ToV1{}
+
+type ExtraV0ToV1 struct{}
+
+func (ExtraV0ToV1) Schema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{}
+}
+
+func (ExtraV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		return rawState, nil
+	}
+}
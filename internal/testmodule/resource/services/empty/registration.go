@@ -1,6 +1,11 @@
 package empty
 
 import (
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/action"
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/datasource"
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/ephemeral"
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/list"
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/resource"
 	"github.com/magodo/aztfo/internal/testmodule/resource/pluginsdk"
 	"github.com/magodo/aztfo/internal/testmodule/resource/sdk"
 )
@@ -18,6 +23,7 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	resources := map[string]*pluginsdk.Resource{
 		"untyped_resource":          untypedResource(),
 		"untyped_resource_indirect": untypedResourceIndirect(),
+		"untyped_resource_extra":    untypedResourceExtra(),
 	}
 	if true {
 		resources["untyped_resource2"] = untypedResource()
@@ -37,5 +43,41 @@ func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		TypedResource{},
 		TypedResourceIndirect{},
+		TypedResourceExtra{},
+	}
+}
+
+// FrameworkDataSources returns a list of Data Sources implemented by the terraform-plugin-framework
+func (r Registration) FrameworkDataSources() []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewFrameworkDataSource,
+	}
+}
+
+// FrameworkResources returns a list of Resources implemented by the terraform-plugin-framework
+func (r Registration) FrameworkResources() []func() resource.Resource {
+	return []func() resource.Resource{
+		NewFrameworkResource,
+	}
+}
+
+// EphemeralResources returns a list of Ephemeral Resources supported by this Service
+func (r Registration) EphemeralResources() []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewEphemeralResource,
+	}
+}
+
+// ListResources returns a list of List Resources supported by this Service
+func (r Registration) ListResources() []func() list.ListResource {
+	return []func() list.ListResource{
+		NewListResource,
+	}
+}
+
+// Actions returns a list of Actions supported by this Service
+func (r Registration) Actions() []func() action.Action {
+	return []func() action.Action{
+		NewAction,
 	}
 }
@@ -0,0 +1,68 @@
+package empty
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/resource/pluginsdk"
+	"github.com/magodo/aztfo/internal/testmodule/resource/sdk"
+	"github.com/magodo/aztfo/internal/testmodule/resource/services/empty/migration"
+)
+
+var (
+	_ sdk.ResourceWithCustomImporter = TypedResourceExtra{}
+	_ sdk.ResourceWithCustomizeDiff  = TypedResourceExtra{}
+	_ sdk.ResourceWithStateMigration = TypedResourceExtra{}
+)
+
+type TypedResourceExtra struct{}
+
+func (t TypedResourceExtra) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return nil
+		},
+	}
+}
+
+func (t TypedResourceExtra) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return nil
+		},
+	}
+}
+
+func (t TypedResourceExtra) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return nil
+		},
+	}
+}
+
+func (t TypedResourceExtra) CustomImporter() sdk.ResourceRunFunc {
+	return func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+		return nil
+	}
+}
+
+func (t TypedResourceExtra) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return nil
+		},
+	}
+}
+
+func (t TypedResourceExtra) StateUpgraders() sdk.StateUpgradeData {
+	return sdk.StateUpgradeData{
+		SchemaVersion: 1,
+		Upgraders: map[int]pluginsdk.StateUpgrade{
+			0: migration.ExtraV0ToV1{},
+		},
+	}
+}
+
+func (t TypedResourceExtra) ResourceType() string {
+	return "typed_resource_extra"
+}
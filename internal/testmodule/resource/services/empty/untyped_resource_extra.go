@@ -0,0 +1,44 @@
+package empty
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/resource/pluginsdk"
+	"github.com/magodo/aztfo/internal/testmodule/resource/services/empty/migration"
+)
+
+func untypedResourceExtra() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: untypedResourceCreate,
+		Read:   untypedResourceRead,
+		Update: untypedResourceUpdate,
+		Delete: untypedResourceDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			return nil
+		}),
+
+		CustomizeDiff: untypedResourceExtraCustomizeDiffAll(pluginsdk.CustomizeDiffShim(untypedResourceExtraCustomizeDiff)),
+
+		SchemaVersion: 1,
+		StateUpgraders: pluginsdk.StateUpgrades(map[int]pluginsdk.StateUpgrade{
+			0: migration.ExtraV0ToV1{},
+		}),
+	}
+}
+
+func untypedResourceExtraCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	return nil
+}
+
+// untypedResourceExtraCustomizeDiffAll is a helper of the service package, which is called to build the CustomizeDiff.
+func untypedResourceExtraCustomizeDiffAll(funcs ...pluginsdk.CustomizeDiffFunc) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		for _, f := range funcs {
+			if err := f(ctx, d, meta); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,34 @@
+package frameworkwrapper
+
+import (
+	"github.com/magodo/aztfo/internal/testmodule/resource/sdk"
+)
+
+type Registration struct{}
+
+// FrameworkDataSources returns a list of Data Sources implemented by the provider's framework wrapper
+func (r Registration) FrameworkDataSources() []sdk.FrameworkWrappedDataSource {
+	return []sdk.FrameworkWrappedDataSource{
+		WrappedDataSource{},
+	}
+}
+
+// FrameworkResources returns a list of Resources implemented by the provider's framework wrapper
+func (r Registration) FrameworkResources() []sdk.FrameworkWrappedResource {
+	return []sdk.FrameworkWrappedResource{
+		&WrappedResource{},
+		NewWrappedResourceCtor(),
+	}
+}
+
+// EphemeralResources returns a list of Ephemeral Resources, which is built in an unrecognized way
+func (r Registration) EphemeralResources() []sdk.FrameworkWrappedResource {
+	var resources []sdk.FrameworkWrappedResource
+	resources = append(resources, &WrappedResource{})
+	return resources
+}
+
+// ListResources returns no List Resources
+func (r Registration) ListResources() []sdk.FrameworkWrappedResource {
+	return nil
+}
@@ -0,0 +1,28 @@
+package frameworkwrapper
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/hashicorpsdk"
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/datasource"
+	"github.com/magodo/aztfo/internal/testmodule/resource/sdk"
+)
+
+var _ sdk.FrameworkWrappedDataSource = WrappedDataSource{}
+
+type WrappedDataSource struct{}
+
+func (d WrappedDataSource) ResourceType() string {
+	return "wrapped_datasource"
+}
+
+func (d WrappedDataSource) ModelObject() any {
+	return &hashicorpsdk.Foo{}
+}
+
+func (d WrappedDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse, metadata sdk.ResourceMetaData, state any) {
+	client := hashicorpsdk.FooClientNative{}
+	if _, err := client.Get(ctx, hashicorpsdk.FooId{}); err != nil {
+		return
+	}
+}
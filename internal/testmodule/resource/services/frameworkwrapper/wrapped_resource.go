@@ -0,0 +1,56 @@
+package frameworkwrapper
+
+import (
+	"context"
+
+	"github.com/magodo/aztfo/internal/testmodule/hashicorpsdk"
+	"github.com/magodo/aztfo/internal/testmodule/resource/framework/resource"
+	"github.com/magodo/aztfo/internal/testmodule/resource/sdk"
+)
+
+var _ sdk.FrameworkWrappedResourceWithImport = &WrappedResource{}
+
+type WrappedResource struct{}
+
+func (r *WrappedResource) ResourceType() string {
+	return "wrapped_resource"
+}
+
+func (r *WrappedResource) ModelObject() any {
+	return &hashicorpsdk.Foo{}
+}
+
+func (r *WrappedResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse, metadata sdk.ResourceMetaData, plan any) {
+	client := hashicorpsdk.FooClientNative{}
+	if err := client.CreateThenPoll(ctx, hashicorpsdk.FooId{}, hashicorpsdk.Foo{}); err != nil {
+		return
+	}
+}
+
+func (r *WrappedResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse, metadata sdk.ResourceMetaData, state any) {
+	client := hashicorpsdk.FooClientNative{}
+	if _, err := client.Get(ctx, hashicorpsdk.FooId{}); err != nil {
+		return
+	}
+}
+
+func (r *WrappedResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse, metadata sdk.ResourceMetaData, plan any, state any) {
+}
+
+func (r *WrappedResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse, metadata sdk.ResourceMetaData, state any) {
+}
+
+func (r *WrappedResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse, metadata sdk.ResourceMetaData) {
+}
+
+type WrappedResourceCtor struct {
+	WrappedResource
+}
+
+func NewWrappedResourceCtor() sdk.FrameworkWrappedResource {
+	return &WrappedResourceCtor{}
+}
+
+func (r *WrappedResourceCtor) ResourceType() string {
+	return "wrapped_resource_ctor"
+}
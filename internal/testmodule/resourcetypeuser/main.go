@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type FooId struct {
+	SubscriptionId    string
+	ResourceGroupName string
+	FooName           string
+}
+
+func (id FooId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Foo/foos/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroupName, id.FooName)
+}
+
+func ParseFooID(input string) (*FooId, error) {
+	return &FooId{}, nil
+}
+
+type BarId struct {
+	SubscriptionId    string
+	ResourceGroupName string
+	FooName           string
+	BarName           string
+}
+
+func (id *BarId) ID() string {
+	return FooId{SubscriptionId: id.SubscriptionId, ResourceGroupName: id.ResourceGroupName, FooName: id.FooName}.ID() + "/bars/" + id.BarName
+}
+
+func ParseBarID(input string) (BarId, error) {
+	return BarId{}, nil
+}
+
+func read(d *schema.ResourceData, meta interface{}) error {
+	id, err := ParseFooID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// The resource group id is not parsed from the Terraform resource ID.
+	rgId, err := commonids.ParseResourceGroupID(d.Get("resource_group_id").(string))
+	if err != nil {
+		return err
+	}
+	_, _ = id, rgId
+	return nil
+}
+
+func importer(id string) error {
+	_, err := ParseBarID(id)
+	return err
+}
+
+func readResourceGroup(d *schema.ResourceData, meta interface{}) error {
+	_, err := commonids.ParseResourceGroupID(d.Id())
+	return err
+}
+
+func main() {
+	read(nil, nil)
+	importer("")
+	readResourceGroup(nil, nil)
+}
@@ -6,26 +6,67 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"regexp"
+	"maps"
+	"os"
 	"runtime"
 	"sort"
-	"strings"
 
 	"github.com/magodo/workerpool"
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/ssa"
 )
 
+// subcommands are the commands that work on the existing aztfo reports, keyed by the command name.
+var subcommands = map[string]func(args []string) error{
+	"rbac":      runRBAC,
+	"plan":      runPlan,
+	"diff":      runDiff,
+	"query":     runQuery,
+	"sensitive": runSensitive,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	flagDir := flag.String("chdir", ".", "terraform-provider-azurerm root directory")
-	flagResources := flag.String("resources", "", `A comma separated resource types to analyze. For data source, add the prefix "data.".`)
+	flagConfig := flag.String("config", DefaultProfile, "The discovery rules, either the name of a built-in profile, or the path to a YAML/JSON config file")
+	flagResources := flag.String("resources", "", `A comma separated resource types to analyze. For the other kinds, add the prefix, i.e. "data.", "ephemeral.", "list." or "action.".`)
 	flagDebug := flag.Bool("debug", false, "Enable debug log")
+	flagCallGraph := flag.String("callgraph", CallGraphAlgorithmStatic, "The call graph algorithm, one of static, cha, rta and vta")
+	flagCompareCallGraph := flag.String("compare-callgraph", "", "Additionally analyze with this call graph algorithm, and report the differences of the results to the stderr")
+	flagKeepGoing := flag.Bool("keep-going", false, "Keep going when failed to analyze a resource or SDK method, which is skipped and recorded as a diagnostic")
+	flagDiagnostics := flag.String("diagnostics", "-", `The file to write the diagnostics in JSON in the keep-going mode, or "-" for the stderr`)
+	flagEvidence := flag.Bool("evidence", false, "Record the call path from the resource function to the SDK function of each API operation")
+	flagPolling := flag.Bool("polling", false, "Record the polling operations of the long running operations and the custom pollers")
 	flag.Usage = func() {
 		fmt.Println(`Usage: aztfo [options] <packages>
+       aztfo <subcommand> [options] <args>
 
 Arguments:
   - packages 
 	The Go package pattern (default "./internal/...").
 	Note that this is fixed in most of the time as it is a whole program analysis.
 
+Subcommands:
+  - rbac
+	Generates the Azure RBAC custom role definitions from an aztfo report.
+  - plan
+	Computes the API operations required for applying a Terraform plan.
+  - diff
+	Reports the differences of the API operations between two aztfo reports.
+  - query
+	Looks up the Terraform resources and verbs that call the matched API operations.
+  - sensitive
+	Reports the Terraform resources and verbs that call the sensitive API operations.
+
 Options:`)
 		flag.PrintDefaults()
 	}
@@ -42,40 +83,35 @@ Options:`)
 		log.SetOutput(io.Discard)
 	}
 
-	pkgPathPrefixes := []string{
-		"github.com/hashicorp/terraform-provider-azurerm",
-		"github.com/hashicorp/go-azure-sdk",
-		"github.com/Azure/azure-sdk-for-go",
-		"github.com/jackofallops/kermit",
+	var diags *Diagnostics
+	if *flagKeepGoing {
+		diags = &Diagnostics{}
 	}
-	pkgs, graph, err := loadPackages(*flagDir, pkgPathPrefixes, patterns)
+
+	cfg, err := loadConfig(*flagConfig)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
-
-	// Find per resource information
-	var servicePkgs Packages
-	servicePkgPattern := regexp.MustCompile(`^github.com/hashicorp/terraform-provider-azurerm/internal/services/[\w-]+$`)
-	for _, pkg := range pkgs {
-		if servicePkgPattern.MatchString(pkg.pkg.PkgPath) {
-			servicePkgs = append(servicePkgs, pkg)
-		}
+	pkgs, err := loadPackages(*flagDir, patterns)
+	if err != nil {
+		fatal(err)
+	}
+	graph, err := buildCallGraph(pkgs, *flagCallGraph, cfg.PackagePathPrefixes)
+	if err != nil {
+		fatal(err)
 	}
 
-	resources, err := findResources(servicePkgs)
+	// Find per resource information
+	resources, err := findResources(cfg.servicePackages(pkgs), cfg.Resource, diags)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	if *flagResources != "" {
 		filteredResources := ResourceInfos{}
-		for res := range strings.SplitSeq(*flagResources, ",") {
-			targetResId := ResourceId{
-				Name:         strings.TrimPrefix(res, "data."),
-				IsDataSource: strings.HasPrefix(res, "data."),
-			}
+		for _, targetResId := range parseResourceIds(*flagResources) {
 			info, ok := resources[targetResId]
 			if !ok {
-				log.Printf("WARNING: resource type %q not found\n", res)
+				log.Printf("WARNING: resource type %q not found\n", targetResId.Address())
 				continue
 			}
 			filteredResources[targetResId] = info
@@ -84,12 +120,70 @@ Options:`)
 	}
 
 	// Find sdk functions
-	sdkFunctions, err := findSDKAPIFuncs(pkgs)
+	sdkFunctions, err := findSDKAPIFuncs(pkgs, cfg.sdkAnalyzers(), diags)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 
-	// For each resource method, find the reachable SDK functions, using static analysis.
+	opts := analyzeOptions{withEvidence: *flagEvidence, permissions: cfg.Permissions, sensitive: cfg.sensitiveRules()}
+	if *flagPolling {
+		opts.customPollers = findCustomPollers(pkgs)
+	}
+	results, err := analyze(graph, resources, sdkFunctions, opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	if *flagCompareCallGraph != "" {
+		cmpGraph, err := buildCallGraph(pkgs, *flagCompareCallGraph, cfg.PackagePathPrefixes)
+		if err != nil {
+			fatal(err)
+		}
+		cmpResults, err := analyze(cmpGraph, resources, sdkFunctions, analyzeOptions{})
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Fprintf(os.Stderr, "Differences of the results from call graph algorithm %q to %q:\n", *flagCallGraph, *flagCompareCallGraph)
+		writeDiffText(os.Stderr, diffResults(results, cmpResults))
+	}
+
+	if *flagKeepGoing {
+		if err := writeDiagnostics(*flagDiagnostics, diags.Items()); err != nil {
+			fatal(err)
+		}
+	}
+
+	b, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		fatal(fmt.Errorf("marshal the result: %v", err))
+	}
+
+	fmt.Println(string(b))
+}
+
+// fatal prints the error to the stderr and exits. It is used instead of log.Fatal, as the log output is discarded unless
+// -debug is specified.
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// analyzeOptions controls the additional information recorded by analyze.
+type analyzeOptions struct {
+	// withEvidence records the call path of each API operation.
+	withEvidence bool
+	// customPollers enables recording the polling operations, with the custom pollers of the provider.
+	// A nil value disables it.
+	customPollers CustomPollers
+	// permissions records the permissions required by the API operations of each verb, if not empty.
+	permissions PermissionRules
+	// sensitive tags the sensitive API operations of each verb, if not empty.
+	sensitive SensitiveRules
+}
+
+// analyze finds the reachable SDK functions for each resource method using the call graph, and returns the results sorted.
+func analyze(graph *callgraph.Graph, resources ResourceInfos, sdkFunctions SDKFunctions, opts analyzeOptions) (Results, error) {
+	// For each resource method, find the reachable SDK functions, using the call graph.
 	var results Results
 	wp := workerpool.NewWorkPool(runtime.NumCPU())
 	n := 0
@@ -101,29 +195,135 @@ Options:`)
 		results = append(results, result)
 		return nil
 	})
+	var finalStates LROFinalStates
+	if opts.customPollers != nil {
+		finalStates = newLROFinalStates(sdkFunctions)
+	}
 	for resId, funcs := range resources {
 		wp.AddTask(func() (any, error) {
 			result := Result{Id: resId}
+			evidence := map[string]OperationEvidences{}
+			polling := map[string]PollingOperations{}
+			pollingOf := func(f *ssa.Function, ops APIOperations) PollingOperations {
+				if opts.customPollers == nil {
+					return nil
+				}
+				return resPollingOperations(graph, f, ops, sdkFunctions, finalStates, opts.customPollers)
+			}
+			reach := func(f *ssa.Function) (APIOperations, OperationEvidences) {
+				return resReachSDK(graph, f, sdkFunctions, opts.withEvidence)
+			}
+			// reachAll unions the API operations reachable from any of the functions.
+			reachAll := func(fs []*ssa.Function) (APIOperations, OperationEvidences, PollingOperations) {
+				var (
+					ops  APIOperations
+					evs  OperationEvidences
+					pops PollingOperations
+				)
+				for _, f := range fs {
+					fops, fevs := reach(f)
+					ops.Union(fops)
+					evs.Union(fevs)
+					pops.Union(pollingOf(f, fops))
+				}
+				sort.Sort(ops)
+				return ops, evs, pops
+			}
 			if f := funcs.R; f != nil {
-				result.Read = resReachSDK(graph, funcs.R, sdkFunctions)
+				result.Read, evidence["read"] = reach(funcs.R)
+				polling["read"] = pollingOf(f, result.Read)
 			}
-			if !resId.IsDataSource {
+			if resId.Kind == ResourceKindResource {
 				if f := funcs.C; f != nil {
-					result.Create = resReachSDK(graph, funcs.C, sdkFunctions)
+					ops, evs := reach(funcs.C)
+					pops := pollingOf(f, ops)
 					// Union the read functions as create will always call the read at the end.
 					// This is not necessary for untyped sdk as the read is called explicitly,
 					// while it is necessary for the typed sdk, as the read is implicitly called via the framework.
-					result.Create.Union(result.Read)
+					ops.Union(result.Read)
+					evs.Union(evidence["read"])
+					pops.Union(polling["read"])
+					result.Create, evidence["create"], polling["create"] = ops, evs, pops
 				}
 				if f := funcs.U; f != nil {
 					// Union the read functions as update will always call the read at the end.
 					// This is not necessary for untyped sdk as the read is called explicitly,
 					// while it is necessary for the typed sdk, as the read is implicitly called via the framework.
-					result.Update = resReachSDK(graph, funcs.U, sdkFunctions)
-					result.Update.Union(result.Read)
+					ops, evs := reach(funcs.U)
+					pops := pollingOf(f, ops)
+					ops.Union(result.Read)
+					evs.Union(evidence["read"])
+					pops.Union(polling["read"])
+					result.Update, evidence["update"], polling["update"] = ops, evs, pops
 				}
 				if f := funcs.D; f != nil {
-					result.Delete = resReachSDK(graph, funcs.D, sdkFunctions)
+					result.Delete, evidence["delete"] = reach(funcs.D)
+					polling["delete"] = pollingOf(f, result.Delete)
+				}
+				if len(funcs.Import) != 0 {
+					// Union the read functions as import will always call the read at the end.
+					ops, evs, pops := reachAll(funcs.Import)
+					ops.Union(result.Read)
+					evs.Union(evidence["read"])
+					pops.Union(polling["read"])
+					result.Import, evidence["import"], polling["import"] = ops, evs, pops
+				}
+				result.Plan, evidence["plan"], polling["plan"] = reachAll(funcs.Plan)
+				result.Migrate, evidence["migrate"], polling["migrate"] = reachAll(funcs.Migrate)
+				result.PrimaryResourceType = primaryResourceType(result, idParserResourceTypes(append([]*ssa.Function{funcs.R}, funcs.Import...)))
+			}
+			if resId.Kind == ResourceKindEphemeral {
+				if f := funcs.Open; f != nil {
+					result.Open, evidence["open"] = reach(f)
+					polling["open"] = pollingOf(f, result.Open)
+				}
+				if f := funcs.Renew; f != nil {
+					result.Renew, evidence["renew"] = reach(f)
+					polling["renew"] = pollingOf(f, result.Renew)
+				}
+				if f := funcs.Close; f != nil {
+					result.Close, evidence["close"] = reach(f)
+					polling["close"] = pollingOf(f, result.Close)
+				}
+			}
+			if f := funcs.List; f != nil {
+				result.List, evidence["list"] = reach(f)
+				polling["list"] = pollingOf(f, result.List)
+			}
+			if f := funcs.Invoke; f != nil {
+				result.Invoke, evidence["invoke"] = reach(f)
+				polling["invoke"] = pollingOf(f, result.Invoke)
+			}
+			if opts.withEvidence {
+				maps.DeleteFunc(evidence, func(_ string, evs OperationEvidences) bool { return len(evs) == 0 })
+				if len(evidence) != 0 {
+					result.Evidence = evidence
+				}
+			}
+			maps.DeleteFunc(polling, func(_ string, pops PollingOperations) bool { return len(pops) == 0 })
+			if len(polling) != 0 {
+				result.Polling = polling
+			}
+			if len(opts.permissions) != 0 {
+				permissions := map[string][]string{}
+				for _, vo := range result.VerbOperations() {
+					if perms := opts.permissions.permissions(vo.Operations); len(perms) != 0 {
+						permissions[vo.Verb] = perms
+					}
+				}
+				if len(permissions) != 0 {
+					result.Permissions = permissions
+				}
+			}
+			if len(opts.sensitive) != 0 {
+				sensitive := map[string]SensitiveOperations{}
+				for _, vo := range result.VerbOperations() {
+					if sops := opts.sensitive.classify(vo.Operations); len(sops) != 0 {
+						sensitive[vo.Verb] = sops
+					}
+				}
+				if len(sensitive) != 0 {
+					result.Sensitive = sensitive
 				}
 			}
 			return result, nil
@@ -132,15 +332,9 @@ Options:`)
 	}
 
 	if err := wp.Done(); err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	sort.Sort(results)
-
-	b, err := json.MarshalIndent(results, "", "  ")
-	if err != nil {
-		log.Fatalf("marshal the result: %v", err)
-	}
-
-	fmt.Println(string(b))
+	return results, nil
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 
-	"golang.org/x/tools/go/callgraph"
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/go/ssa/ssautil"
@@ -26,18 +25,18 @@ func (pkgs Packages) Pkgs() []*packages.Package {
 	return pkgpkgs
 }
 
-func loadPackages(dir string, pkgPathPrefixes []string, patterns []string) (Packages, *callgraph.Graph, error) {
+func loadPackages(dir string, patterns []string) (Packages, error) {
 	log.Println("Load packages: begin")
 	defer log.Println("Load packages: end")
 
 	// Loading Go packages
-	cfg := packages.Config{Dir: dir, Mode: packages.LoadAllSyntax}
+	cfg := packages.Config{Dir: dir, Mode: packages.LoadAllSyntax | packages.NeedModule}
 	pkgs, err := packages.Load(&cfg, patterns...)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	if packages.PrintErrors(pkgs) > 0 {
-		return nil, nil, errors.New("packages contain errors")
+		return nil, errors.New("packages contain errors")
 	}
 
 	// Build SSA for the specified "pkgs" and their dependencies.
@@ -54,12 +53,5 @@ func loadPackages(dir string, pkgPathPrefixes []string, patterns []string) (Pack
 		packages = append(packages, Package{pkg: pkgs[i], ssa: ssapkgs[i]})
 	}
 
-	// Build callgraph
-	graph := CallGraph(prog)
-
-	if len(pkgPathPrefixes) != 0 {
-		trimCallGraph(graph, pkgPathPrefixes)
-	}
-
-	return packages, graph, nil
+	return packages, nil
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+)
+
+// PermissionRule maps the API operations to the permissions required to invoke them, e.g. the Microsoft Graph
+// application permissions.
+type PermissionRule struct {
+	// Kinds are the operation kinds (e.g. "GET") that the rule applies to. Empty means any kind.
+	Kinds []OperationKind `yaml:"kinds"`
+	// PathPattern is the regexp matched against the normalized API path (i.e. upper cased, with "{}" as the placeholders),
+	// e.g. "^/APPLICATIONS(/|$)".
+	PathPattern string `yaml:"path_pattern"`
+	// Permissions are the permissions, any of which is sufficient to invoke the matched API operations.
+	Permissions []string `yaml:"permissions"`
+
+	pathRegexp *regexp.Regexp
+}
+
+type PermissionRules []PermissionRule
+
+// match tells whether the rule applies to the API operation.
+func (rule PermissionRule) match(op APIOperation) bool {
+	if len(rule.Kinds) != 0 && !slices.Contains(rule.Kinds, op.Kind) {
+		return false
+	}
+	return rule.pathRegexp.MatchString(op.Path)
+}
+
+// permissions returns the sorted permissions of the rules that apply to any of the API operations.
+func (rules PermissionRules) permissions(ops APIOperations) []string {
+	var perms []string
+	for _, op := range ops {
+		for _, rule := range rules {
+			if !rule.match(op) {
+				continue
+			}
+			for _, perm := range rule.Permissions {
+				if !slices.Contains(perms, perm) {
+					perms = append(perms, perm)
+				}
+			}
+		}
+	}
+	sort.Strings(perms)
+	return perms
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissions(t *testing.T) {
+	t.Parallel()
+	cfg, err := loadConfig("azuread")
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		ops    APIOperations
+		expect []string
+	}{
+		{
+			name: "no operation",
+		},
+		{
+			name: "read",
+			ops: APIOperations{
+				{Kind: OperationKindGet, Version: "v1.0", Path: "/APPLICATIONS/{}"},
+				{Kind: OperationKindGet, Version: "v1.0", Path: "/APPLICATIONS/{}/OWNERS"},
+			},
+			expect: []string{"Application.Read.All"},
+		},
+		{
+			name: "write",
+			ops: APIOperations{
+				{Kind: OperationKindGet, Version: "beta", Path: "/GROUPS/{}"},
+				{Kind: OperationKindPatch, Version: "beta", Path: "/GROUPS/{}"},
+				{Kind: OperationKindPost, Version: "v1.0", Path: "/APPLICATIONS/{}/OWNERS/$REF"},
+			},
+			expect: []string{"Application.ReadWrite.All", "Group.Read.All", "Group.ReadWrite.All"},
+		},
+		{
+			name: "not matched",
+			ops: APIOperations{
+				{Kind: OperationKindGet, Version: "v1.0", Path: "/GROUPSETTINGS/{}"},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expect, cfg.Permissions.permissions(c.ops))
+		})
+	}
+}
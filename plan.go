@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+)
+
+// tfPlan is the subset of the Terraform plan JSON representation (i.e. the output of "terraform show -json <planfile>") used by aztfo.
+type tfPlan struct {
+	ResourceChanges []tfResourceChange `json:"resource_changes"`
+}
+
+type tfResourceChange struct {
+	Address string `json:"address"`
+	// Mode is either "managed" or "data"
+	Mode   string `json:"mode"`
+	Type   string `json:"type"`
+	Change struct {
+		// Actions is one of: ["no-op"], ["create"], ["read"], ["update"], ["delete", "create"], ["create", "delete"], ["delete"].
+		Actions []string `json:"actions"`
+	} `json:"change"`
+}
+
+// planOperations computes the API operations required for applying the Terraform plan, based on the results.
+// If includeRefresh is true, the operations for refreshing the existing resources (i.e. the ones to be deleted, updated or
+// unchanged) and data sources, as well as the ones for migrating the state and planning the managed resources are also
+// included, which are needed when the plan is not applied from a saved plan file.
+// It returns the operations and the addresses of the resource changes that have no corresponding result.
+func planOperations(plan tfPlan, results Results, includeRefresh bool) (APIOperations, []string) {
+	resultMap := map[ResourceId]Result{}
+	for _, res := range results {
+		resultMap[res.Id] = res
+	}
+
+	ops := APIOperations{}
+	var unknowns []string
+	for _, rc := range plan.ResourceChanges {
+		res, ok := resultMap[ResourceId{Name: rc.Type, Kind: planResourceKind(rc.Mode)}]
+		if !ok {
+			unknowns = append(unknowns, rc.Address)
+			continue
+		}
+		for _, action := range rc.Change.Actions {
+			switch action {
+			case "create":
+				ops.Union(res.Create)
+			case "update":
+				ops.Union(res.Update)
+			case "delete":
+				// A replacement is represented as both "delete" and "create".
+				ops.Union(res.Delete)
+			case "read":
+				ops.Union(res.Read)
+			}
+		}
+		// Without a saved plan, the existing resources and data sources are refreshed before the plan is recomputed.
+		if includeRefresh && slices.ContainsFunc(rc.Change.Actions, func(action string) bool {
+			return action == "delete" || action == "update" || action == "no-op"
+		}) {
+			ops.Union(res.Read)
+		}
+		// Without a saved plan, the plan is recomputed during the apply, which involves the state migration and
+		// the customize diff of the managed resources.
+		if includeRefresh && rc.Mode != "data" {
+			ops.Union(res.Migrate)
+			ops.Union(res.Plan)
+		}
+	}
+	sort.Sort(ops)
+	return ops, unknowns
+}
+
+// planResourceKind returns the resource kind of the resource change mode.
+func planResourceKind(mode string) ResourceKind {
+	if mode == "data" {
+		return ResourceKindDataSource
+	}
+	return ResourceKindResource
+}
+
+func runPlan(args []string) error {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	flagIncludeRefresh := fs.Bool("include-refresh", false, "Include the read operations of the existing (i.e. deleted, updated or unchanged) resources and data sources, and the migrate and plan operations of the managed resources, which are needed when not applying a saved plan")
+	flagRBAC := fs.Bool("rbac", false, "Output the Azure RBAC actions instead of the API operations")
+	fs.Usage = func() {
+		fmt.Println(`Usage: aztfo plan [options] <report> <plan>
+
+Computes the API operations required for applying a Terraform plan.
+
+Arguments:
+  - report
+	The aztfo output file.
+  - plan
+	The Terraform plan in JSON, i.e. the output of "terraform show -json <planfile>".
+
+Options:`)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("expect exactly two arguments, got=%d", fs.NArg())
+	}
+
+	results, err := readResults(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("reading %q: %v", fs.Arg(1), err)
+	}
+	var plan tfPlan
+	if err := json.Unmarshal(b, &plan); err != nil {
+		return fmt.Errorf("unmarshal %q: %v", fs.Arg(1), err)
+	}
+
+	ops, unknowns := planOperations(plan, results, *flagIncludeRefresh)
+	for _, addr := range unknowns {
+		fmt.Fprintf(os.Stderr, "WARNING: %q not found in the report, skipped\n", addr)
+	}
+
+	var output any = ops
+	if *flagRBAC {
+		actions, err := rbacActions(ops)
+		if err != nil {
+			return err
+		}
+		output = actions
+	}
+	b, err = json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal the output: %v", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
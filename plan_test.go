@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanOperations(t *testing.T) {
+	t.Parallel()
+	var (
+		opGet    = APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"}
+		opPut    = APIOperation{Kind: OperationKindPut, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}", IsLRO: true}
+		opPatch  = APIOperation{Kind: OperationKindPatch, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"}
+		opDelete = APIOperation{Kind: OperationKindDelete, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"}
+		opBarGet = APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/BARS/{}"}
+		opSkuGet = APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/PROVIDERS/MICROSOFT.FOO/SKUS"}
+	)
+	results := Results{
+		{
+			Id:     ResourceId{Name: "azurerm_foo"},
+			Create: APIOperations{opGet, opPut},
+			Read:   APIOperations{opGet},
+			Update: APIOperations{opGet, opPatch},
+			Delete: APIOperations{opDelete},
+			Plan:   APIOperations{opSkuGet},
+		},
+		{
+			Id:   ResourceId{Name: "azurerm_bar", Kind: ResourceKindDataSource},
+			Read: APIOperations{opBarGet},
+		},
+	}
+
+	planJSON := `{
+  "resource_changes": [
+    {"address": "azurerm_foo.replace", "mode": "managed", "type": "azurerm_foo", "change": {"actions": ["delete", "create"]}},
+    {"address": "azurerm_foo.noop", "mode": "managed", "type": "azurerm_foo", "change": {"actions": ["no-op"]}},
+    {"address": "data.azurerm_bar.test", "mode": "data", "type": "azurerm_bar", "change": {"actions": ["no-op"]}},
+    {"address": "random_string.test", "mode": "managed", "type": "random_string", "change": {"actions": ["create"]}}
+  ]
+}`
+	var plan tfPlan
+	require.NoError(t, json.Unmarshal([]byte(planJSON), &plan))
+
+	ops, unknowns := planOperations(plan, results, false)
+	require.Equal(t, APIOperations{opDelete, opGet, opPut}, ops)
+	require.Equal(t, []string{"random_string.test"}, unknowns)
+
+	ops, _ = planOperations(plan, results, true)
+	require.Equal(t, APIOperations{opSkuGet, opBarGet, opDelete, opGet, opPut}, ops)
+
+	// The resources to be deleted are also refreshed.
+	planJSON = `{
+  "resource_changes": [
+    {"address": "azurerm_foo.delete", "mode": "managed", "type": "azurerm_foo", "change": {"actions": ["delete"]}}
+  ]
+}`
+	plan = tfPlan{}
+	require.NoError(t, json.Unmarshal([]byte(planJSON), &plan))
+
+	ops, _ = planOperations(plan, results, false)
+	require.Equal(t, APIOperations{opDelete}, ops)
+
+	ops, _ = planOperations(plan, results, true)
+	require.Equal(t, APIOperations{opSkuGet, opDelete, opGet}, ops)
+}
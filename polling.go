@@ -0,0 +1,302 @@
+package main
+
+import (
+	"cmp"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/ssa"
+)
+
+const (
+	PollingViaAzureAsyncOperation = "Azure-AsyncOperation"
+	PollingViaLocation            = "Location"
+	PollingViaOperationLocation   = "Operation-Location"
+	PollingViaOriginalURI         = "original-uri"
+	PollingViaCustom              = "custom"
+)
+
+// The final state vias of the LROs, i.e. where the poller fetches the final state of the LRO from, as pinned by the SDK
+// (e.g. the FinalStateVia of the Track2 SDK poller options).
+const (
+	FinalStateViaAzureAsyncOperation = "azure-async-operation"
+	FinalStateViaLocation            = "location"
+	FinalStateViaOriginalURI         = "original-uri"
+	FinalStateViaOperationLocation   = "operation-location"
+)
+
+// PollingOperation is an API operation used for polling, derived from a long running operation (LRO) or a custom poller.
+type PollingOperation struct {
+	// LRO is the long running operation being polled. It is nil for the custom pollers.
+	LRO *APIOperation `json:"lro,omitempty"`
+	// Via is how the polling operation is determined, one of:
+	//
+	//   - Azure-AsyncOperation: The poller follows the URL in the "Azure-AsyncOperation" response header of the LRO, which
+	//     is preferred over the "Location" if both are returned
+	//   - Location: The poller follows the URL in the "Location" response header of the LRO
+	//   - Operation-Location: The poller follows the URL in the "Operation-Location" response header of the LRO
+	//   - original-uri: The poller gets the final state of the LRO from its original URI
+	//   - custom: The polling operation is called by a custom poller of the provider
+	Via string `json:"via"`
+	// FinalStateVia is where the poller fetches the final state of the LRO from, if it is pinned by the SDK. It is empty for
+	// the custom pollers, and for the LROs that don't pin it.
+	FinalStateVia string `json:"final_state_via,omitempty"`
+	// Poller is the custom poller type, e.g. "custompollers.fooPoller".
+	Poller string `json:"poller,omitempty"`
+	// Operation is the polling operation. It is nil for the response headers, as the URL is only known in runtime.
+	Operation *APIOperation `json:"operation,omitempty"`
+}
+
+type PollingOperations []PollingOperation
+
+func (ops PollingOperations) Len() int {
+	return len(ops)
+}
+
+func (ops PollingOperations) Less(i int, j int) bool {
+	return comparePollingOperation(ops[i], ops[j]) < 0
+}
+
+func (ops PollingOperations) Swap(i int, j int) {
+	ops[i], ops[j] = ops[j], ops[i]
+}
+
+func comparePollingOperation(x, y PollingOperation) int {
+	compareOp := func(x, y *APIOperation) int {
+		switch {
+		case x == nil && y == nil:
+			return 0
+		case x == nil:
+			return -1
+		case y == nil:
+			return 1
+		}
+		return cmp.Or(
+			cmp.Compare(x.Path, y.Path),
+			cmp.Compare(x.Kind, y.Kind),
+			cmp.Compare(x.Version, y.Version),
+		)
+	}
+	return cmp.Or(
+		compareOp(x.LRO, y.LRO),
+		cmp.Compare(x.Via, y.Via),
+		cmp.Compare(x.FinalStateVia, y.FinalStateVia),
+		cmp.Compare(x.Poller, y.Poller),
+		compareOp(x.Operation, y.Operation),
+	)
+}
+
+// Union adds the polling operations of b that don't exist in ops, and keeps ops sorted.
+func (ops *PollingOperations) Union(b PollingOperations) {
+	for _, op := range b {
+		if !slices.ContainsFunc(*ops, func(o PollingOperation) bool { return comparePollingOperation(o, op) == 0 }) {
+			*ops = append(*ops, op)
+		}
+	}
+	slices.SortFunc(*ops, comparePollingOperation)
+}
+
+// LROFinalStates are the final state vias of the LROs, keyed by APIOperation.key of the LROs. An empty final state via
+// means it is not pinned by (one of) the SDK functions of the LRO.
+type LROFinalStates map[APIOperation][]string
+
+// newLROFinalStates collects the final state vias of the LROs, as pinned by the SDK functions.
+func newLROFinalStates(sdkFuncs SDKFunctions) LROFinalStates {
+	m := LROFinalStates{}
+	for _, fn := range sdkFuncs {
+		if !fn.Operation.IsLRO {
+			continue
+		}
+		k := fn.Operation.key()
+		if !slices.Contains(m[k], fn.finalStateVia) {
+			m[k] = append(m[k], fn.finalStateVia)
+		}
+	}
+	return m
+}
+
+// of returns the final state vias of the LRO.
+func (m LROFinalStates) of(op APIOperation) []string {
+	vias, ok := m[op.key()]
+	if !ok {
+		// The API version of the SDK function might be resolved at the call sites.
+		k := op.key()
+		k.Version = "unknown"
+		vias = m[k]
+	}
+	return vias
+}
+
+// lroPollingOperations derives the polling operations of the LRO, for each of its final state vias (an empty one means
+// not pinned by the SDK). The poller follows the URL in the response header that is allowed by the final state via (see
+// pollingHeaders), which is only known in runtime. Additionally, the poller gets the final state from the original URI of
+// the LRO, for the PUT and PATCH (as implied by the ARM RPC), and for the other LROs whose final state via is pinned to
+// "original-uri" by the SDK.
+func lroPollingOperations(op APIOperation, finalStates []string) PollingOperations {
+	if len(finalStates) == 0 {
+		finalStates = []string{""}
+	}
+	lro := op
+	var ops PollingOperations
+	for _, finalState := range finalStates {
+		for _, via := range pollingHeaders(finalState) {
+			ops.Union(PollingOperations{{LRO: &lro, Via: via, FinalStateVia: finalState}})
+		}
+		if op.Kind == OperationKindPut || op.Kind == OperationKindPatch || finalState == FinalStateViaOriginalURI {
+			get := APIOperation{
+				Kind:     OperationKindGet,
+				Version:  op.Version,
+				Path:     op.Path,
+				Plane:    op.Plane,
+				Endpoint: op.Endpoint,
+				ARMPath:  op.ARMPath,
+			}
+			// The GET on the path of an action (e.g. the POST to restart) is not an operation of a resource type.
+			if get.ARMPath.Action != "" {
+				get.ARMPath = ARMPath{}
+			}
+			ops.Union(PollingOperations{{LRO: &lro, Via: PollingViaOriginalURI, FinalStateVia: finalState, Operation: &get}})
+		}
+	}
+	return ops
+}
+
+// pollingHeaders returns the response headers that the poller may follow, for the final state via of the LRO. If it is
+// not pinned, or pinned to the original URI, both the "Azure-AsyncOperation" and "Location" are returned, as the poller
+// decides it from the response in runtime.
+func pollingHeaders(finalState string) []string {
+	switch finalState {
+	case FinalStateViaAzureAsyncOperation:
+		return []string{PollingViaAzureAsyncOperation}
+	case FinalStateViaLocation:
+		return []string{PollingViaLocation}
+	case FinalStateViaOperationLocation:
+		return []string{PollingViaOperationLocation}
+	default:
+		return []string{PollingViaAzureAsyncOperation, PollingViaLocation}
+	}
+}
+
+// CustomPollers are the custom pollers defined in the provider, keyed by the poller type, valued by its Poll method.
+type CustomPollers map[*types.Named]*ssa.Function
+
+// findCustomPollers finds the types defined in the "pkgs" that implement the PollerType interface of the
+// "github.com/hashicorp/go-azure-sdk/sdk/client/pollers" package, i.e. have the method:
+//
+//	Poll(ctx context.Context) (*pollers.PollResult, error)
+func findCustomPollers(pkgs Packages) CustomPollers {
+	pollers := CustomPollers{}
+	for _, pkg := range pkgs {
+		prog := pkg.ssa.Prog
+		for _, mem := range pkg.ssa.Members {
+			mem, ok := mem.(*ssa.Type)
+			if !ok || types.IsInterface(mem.Type()) {
+				continue
+			}
+			named, ok := mem.Type().(*types.Named)
+			if !ok || named.TypeParams() != nil {
+				continue
+			}
+			for _, t := range []types.Type{named, types.NewPointer(named)} {
+				sel := prog.MethodSets.MethodSet(t).Lookup(pkg.ssa.Pkg, "Poll")
+				if sel == nil || !isPollMethod(sel.Type().(*types.Signature)) {
+					continue
+				}
+				if f := prog.MethodValue(sel); f != nil {
+					pollers[named] = f
+					break
+				}
+			}
+		}
+	}
+	return pollers
+}
+
+func isPollMethod(sig *types.Signature) bool {
+	if sig.Params().Len() != 1 || sig.Results().Len() != 2 {
+		return false
+	}
+	ptr, ok := sig.Results().At(0).Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Name() == "PollResult" && strings.HasSuffix(named.Obj().Pkg().Path(), "/sdk/client/pollers")
+}
+
+// resPollingOperations returns the polling operations of the resource function, including the ones derived from
+// the LROs in the ops (that are reachable from the resource function), and the ones called by the custom pollers
+// that are constructed in the functions reachable from the resource function.
+func resPollingOperations(graph *callgraph.Graph, resFunc *ssa.Function, ops APIOperations, sdkFuncs SDKFunctions, finalStates LROFinalStates, customPollers CustomPollers) PollingOperations {
+	var pops PollingOperations
+	for _, op := range ops {
+		if op.IsLRO {
+			pops.Union(lroPollingOperations(op, finalStates.of(op)))
+		}
+	}
+
+	// The custom pollers are called by the SDK via the PollerType interface, which is not followed by the static call graph.
+	// Instead, they are regarded as called by the function that converts them to an interface.
+	seen := map[*ssa.Function]bool{}
+	for _, f := range reachableFuncs(graph, resFunc) {
+		for _, b := range f.Blocks {
+			for _, instr := range b.Instrs {
+				mi, ok := instr.(*ssa.MakeInterface)
+				if !ok {
+					continue
+				}
+				t := mi.X.Type()
+				if ptr, ok := t.(*types.Pointer); ok {
+					t = ptr.Elem()
+				}
+				named, ok := types.Unalias(t).(*types.Named)
+				if !ok {
+					continue
+				}
+				pollFunc, ok := customPollers[named]
+				if !ok || seen[pollFunc] {
+					continue
+				}
+				seen[pollFunc] = true
+				pollOps, _ := resReachSDK(graph, pollFunc, sdkFuncs, false)
+				for _, op := range pollOps {
+					pops.Union(PollingOperations{{
+						Via:       PollingViaCustom,
+						Poller:    named.Obj().Pkg().Name() + "." + named.Obj().Name(),
+						Operation: &op,
+					}})
+				}
+			}
+		}
+	}
+	return pops
+}
+
+// reachableFuncs returns the functions reachable from the function in the call graph, including itself.
+func reachableFuncs(graph *callgraph.Graph, f *ssa.Function) []*ssa.Function {
+	node := graph.Nodes[f]
+	if node == nil {
+		return nil
+	}
+	var funcs []*ssa.Function
+	seen := map[*callgraph.Node]bool{node: true}
+	queue := []*callgraph.Node{node}
+	for len(queue) != 0 {
+		node := queue[0]
+		queue = queue[1:]
+		funcs = append(funcs, node.Func)
+		for _, edge := range node.Out {
+			if seen[edge.Callee] {
+				continue
+			}
+			seen[edge.Callee] = true
+			queue = append(queue, edge.Callee)
+		}
+	}
+	return funcs
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLROPollingOperations(t *testing.T) {
+	t.Parallel()
+	const path = "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"
+	var (
+		foos        = ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"}
+		put         = APIOperation{Kind: OperationKindPut, Version: "2025-04-01", Path: path, ARMPath: foos, IsLRO: true, ExpectedStatusCodes: "200,201"}
+		getPut      = &APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: path, ARMPath: foos}
+		del         = APIOperation{Kind: OperationKindDelete, Version: "2025-04-01", Path: path, ARMPath: foos, IsLRO: true}
+		post        = APIOperation{Kind: OperationKindPost, Version: "2025-04-01", Path: path + "/RESTART", ARMPath: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos", Action: "restart"}, IsLRO: true}
+		getPost     = &APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: path + "/RESTART"}
+		finalStates = LROFinalStates{
+			put.key():  {FinalStateViaAzureAsyncOperation},
+			del.key():  {FinalStateViaLocation},
+			post.key(): {FinalStateViaOriginalURI, ""},
+		}
+		unpinned = APIOperation{Kind: OperationKindDelete, Version: "2025-04-01", Path: path + "/BARS/{}", IsLRO: true}
+	)
+
+	cases := []struct {
+		name   string
+		op     APIOperation
+		expect PollingOperations
+	}{
+		{
+			name: "put with the final state via azure async operation",
+			op:   put,
+			expect: PollingOperations{
+				{LRO: &put, Via: PollingViaAzureAsyncOperation, FinalStateVia: FinalStateViaAzureAsyncOperation},
+				{LRO: &put, Via: PollingViaOriginalURI, FinalStateVia: FinalStateViaAzureAsyncOperation, Operation: getPut},
+			},
+		},
+		{
+			name: "delete with the final state via location",
+			op:   del,
+			expect: PollingOperations{
+				{LRO: &del, Via: PollingViaLocation, FinalStateVia: FinalStateViaLocation},
+			},
+		},
+		{
+			name: "post with the final state via original uri",
+			op:   post,
+			expect: PollingOperations{
+				{LRO: &post, Via: PollingViaAzureAsyncOperation},
+				{LRO: &post, Via: PollingViaAzureAsyncOperation, FinalStateVia: FinalStateViaOriginalURI},
+				{LRO: &post, Via: PollingViaLocation},
+				{LRO: &post, Via: PollingViaLocation, FinalStateVia: FinalStateViaOriginalURI},
+				{LRO: &post, Via: PollingViaOriginalURI, FinalStateVia: FinalStateViaOriginalURI, Operation: getPost},
+			},
+		},
+		{
+			name: "not pinned",
+			op:   unpinned,
+			expect: PollingOperations{
+				{LRO: &unpinned, Via: PollingViaAzureAsyncOperation},
+				{LRO: &unpinned, Via: PollingViaLocation},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expect, lroPollingOperations(c.op, finalStates.of(c.op)))
+		})
+	}
+}
+
+func TestResPollingOperations(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/custompolleruser", []string{"."})
+	require.NoError(t, err)
+
+	a := NewSDKAnalyzerHashicorp(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/hashicorpsdk`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+
+	customPollers := findCustomPollers(pkgs)
+	require.Len(t, customPollers, 1)
+
+	graph, err := buildCallGraph(pkgs, CallGraphAlgorithmStatic, []string{"github.com/magodo/aztfo/internal/testmodule"})
+	require.NoError(t, err)
+
+	mainFunc := pkgs[0].ssa.Func("main")
+	ops, _ := resReachSDK(graph, mainFunc, funcs, false)
+
+	const path = "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"
+	armPath := ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"}
+	lro := APIOperation{Kind: OperationKindPut, Version: "2025-04-01", Path: path, ARMPath: armPath, IsLRO: true, ContentType: "application/json; charset=utf-8", ExpectedStatusCodes: "200,201,202"}
+	// The custom poller is only called via the pollers.PollerType interface.
+	require.Equal(t, APIOperations{lro}, ops)
+
+	require.Equal(t,
+		PollingOperations{
+			{
+				Via:       PollingViaCustom,
+				Poller:    "main.fooPoller",
+				Operation: &APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: path, ARMPath: armPath, ContentType: "application/json; charset=utf-8", ExpectedStatusCodes: "200"},
+			},
+			{LRO: &lro, Via: PollingViaAzureAsyncOperation},
+			{LRO: &lro, Via: PollingViaLocation},
+			{
+				LRO:       &lro,
+				Via:       PollingViaOriginalURI,
+				Operation: &APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: path, ARMPath: armPath},
+			},
+		},
+		resPollingOperations(graph, mainFunc, ops, funcs, newLROFinalStates(funcs), customPollers))
+}
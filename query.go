@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// OperationQuery is the criteria of the API operations to look up. An empty criterion matches any.
+type OperationQuery struct {
+	// PathPattern is the regexp matched against the normalized API path (i.e. upper cased, with "{}" as the placeholders).
+	PathPattern *regexp.Regexp
+	// Kind is the (upper cased) operation kind, e.g. "GET".
+	Kind OperationKind
+	// Version is the API version.
+	Version string
+	// ProviderNamespace is the (case insensitive) resource provider namespace of the ARM operations, e.g. "Microsoft.Foo".
+	ProviderNamespace string
+}
+
+// match tells whether the API operation matches the query.
+func (q OperationQuery) match(op APIOperation) bool {
+	if q.PathPattern != nil && !q.PathPattern.MatchString(op.Path) {
+		return false
+	}
+	if q.Kind != "" && q.Kind != op.Kind {
+		return false
+	}
+	if q.Version != "" && q.Version != op.Version {
+		return false
+	}
+	if q.ProviderNamespace != "" {
+		armPath := op.ARMPath
+		// The reports generated before the "arm_path" is introduced.
+		if armPath == (ARMPath{}) && op.Plane == "" {
+			armPath = parseARMPath(op.Path, op.Kind)
+		}
+		if !strings.EqualFold(q.ProviderNamespace, armPath.ProviderNamespace) {
+			return false
+		}
+	}
+	return true
+}
+
+// QueryMatch is a Terraform resource verb that calls the API operations matched by the query.
+type QueryMatch struct {
+	Id         ResourceId    `json:"id"`
+	Verb       string        `json:"verb"`
+	Operations APIOperations `json:"operations"`
+}
+
+// queryResults looks up the resource verbs that call the API operations matching the query, including the polling
+// operations of the verb, in the order of the resources and verbs.
+func queryResults(results Results, q OperationQuery) []QueryMatch {
+	var matches []QueryMatch
+	for _, res := range results {
+		for _, vo := range res.VerbOperations() {
+			var ops APIOperations
+			for _, op := range vo.Operations {
+				if q.match(op) {
+					ops.Union(APIOperations{op})
+				}
+			}
+			for _, pop := range res.Polling[vo.Verb] {
+				if pop.Operation != nil && q.match(*pop.Operation) {
+					ops.Union(APIOperations{*pop.Operation})
+				}
+			}
+			if len(ops) == 0 {
+				continue
+			}
+			sort.Sort(ops)
+			matches = append(matches, QueryMatch{Id: res.Id, Verb: vo.Verb, Operations: ops})
+		}
+	}
+	return matches
+}
+
+// writeQueryText writes the query matches in a human readable form.
+func writeQueryText(w io.Writer, matches []QueryMatch) {
+	for i, m := range matches {
+		if i == 0 || matches[i-1].Id != m.Id {
+			fmt.Fprintf(w, "%s\n", m.Id)
+		}
+		fmt.Fprintf(w, "  %s:\n", m.Verb)
+		for _, op := range m.Operations {
+			var lro string
+			if op.IsLRO {
+				lro = " (LRO)"
+			}
+			fmt.Fprintf(w, "    %s %s %s%s\n", op.Kind, op.Path, op.Version, lro)
+		}
+	}
+}
+
+func runQuery(args []string) error {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	flagPath := fs.String("path", "", `The regexp matched (case insensitively) against the normalized API path, e.g. "/PROVIDERS/MICROSOFT.FOO/FOOS/{}$"`)
+	flagMethod := fs.String("method", "", `The HTTP method of the API operation, e.g. "PUT"`)
+	flagVersion := fs.String("version", "", "The API version of the API operation")
+	flagNamespace := fs.String("namespace", "", `The resource provider namespace of the ARM operation, e.g. "Microsoft.Foo"`)
+	flagJSON := fs.Bool("json", false, "Output the matches in JSON, instead of the human readable text")
+	fs.Usage = func() {
+		fmt.Println(`Usage: aztfo query [options] <report>
+
+Looks up the Terraform resources and verbs that call the API operations matching all the specified criteria, e.g. the ones
+affected by an API version retirement.
+
+Arguments:
+  - report
+	The aztfo output file, or "-" to read from the stdin (e.g. piped from a fresh run of aztfo).
+
+Options:`)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expect exactly one argument, got=%d", fs.NArg())
+	}
+
+	q := OperationQuery{
+		Kind:              OperationKind(strings.ToUpper(*flagMethod)),
+		Version:           *flagVersion,
+		ProviderNamespace: *flagNamespace,
+	}
+	if *flagPath != "" {
+		p, err := regexp.Compile("(?i)" + *flagPath)
+		if err != nil {
+			return fmt.Errorf("invalid path pattern %q: %v", *flagPath, err)
+		}
+		q.PathPattern = p
+	}
+
+	results, err := readResults(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	matches := queryResults(results, q)
+	if !*flagJSON {
+		writeQueryText(os.Stdout, matches)
+		return nil
+	}
+	if matches == nil {
+		matches = []QueryMatch{}
+	}
+	b, err := json.MarshalIndent(matches, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal the output: %v", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
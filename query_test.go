@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryResults(t *testing.T) {
+	t.Parallel()
+	var (
+		foos     = ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"}
+		opGetV1  = APIOperation{Kind: OperationKindGet, Version: "2024-01-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}", ARMPath: foos}
+		opGetV2  = APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}", ARMPath: foos}
+		opPut    = APIOperation{Kind: OperationKindPut, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}", ARMPath: foos, IsLRO: true}
+		opStatus = APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/PROVIDERS/MICROSOFT.FOO/LOCATIONS/{}/OPERATIONSTATUSES/{}"}
+		// The operation of a report generated before the "arm_path" is introduced.
+		opBarGet = APIOperation{Kind: OperationKindGet, Version: "2024-01-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.BAR/BARS/{}"}
+		opSecret = APIOperation{Kind: OperationKindGet, Version: "7.4", Path: "/SECRETS/{}/{}", Plane: PlaneData, Endpoint: "https://{}.vault.azure.net"}
+	)
+	results := Results{
+		{
+			Id:   ResourceId{Name: "azurerm_bar", Kind: ResourceKindDataSource},
+			Read: APIOperations{opBarGet, opSecret},
+		},
+		{
+			Id:     ResourceId{Name: "azurerm_foo"},
+			Create: APIOperations{opGetV2, opPut},
+			Read:   APIOperations{opGetV2},
+			Import: APIOperations{opGetV1},
+			Polling: map[string]PollingOperations{
+				"create": {{LRO: &opPut, Via: PollingViaAzureAsyncOperation, Operation: &opStatus}},
+			},
+		},
+	}
+
+	cases := []struct {
+		name   string
+		query  OperationQuery
+		expect []QueryMatch
+	}{
+		{
+			name:  "version",
+			query: OperationQuery{Version: "2024-01-01"},
+			expect: []QueryMatch{
+				{Id: ResourceId{Name: "azurerm_bar", Kind: ResourceKindDataSource}, Verb: "read", Operations: APIOperations{opBarGet}},
+				{Id: ResourceId{Name: "azurerm_foo"}, Verb: "import", Operations: APIOperations{opGetV1}},
+			},
+		},
+		{
+			name:  "kind and path",
+			query: OperationQuery{Kind: OperationKindPut, PathPattern: regexp.MustCompile(`(?i)/providers/Microsoft.Foo/foos/{}$`)},
+			expect: []QueryMatch{
+				{Id: ResourceId{Name: "azurerm_foo"}, Verb: "create", Operations: APIOperations{opPut}},
+			},
+		},
+		{
+			name:  "polling operations",
+			query: OperationQuery{PathPattern: regexp.MustCompile(`/OPERATIONSTATUSES/`)},
+			expect: []QueryMatch{
+				{Id: ResourceId{Name: "azurerm_foo"}, Verb: "create", Operations: APIOperations{opStatus}},
+			},
+		},
+		{
+			name:  "provider namespace",
+			query: OperationQuery{ProviderNamespace: "microsoft.bar"},
+			expect: []QueryMatch{
+				{Id: ResourceId{Name: "azurerm_bar", Kind: ResourceKindDataSource}, Verb: "read", Operations: APIOperations{opBarGet}},
+			},
+		},
+		{
+			name:  "provider namespace and version",
+			query: OperationQuery{ProviderNamespace: "Microsoft.Foo", Version: "2025-04-01"},
+			expect: []QueryMatch{
+				{Id: ResourceId{Name: "azurerm_foo"}, Verb: "create", Operations: APIOperations{opStatus, opGetV2, opPut}},
+				{Id: ResourceId{Name: "azurerm_foo"}, Verb: "read", Operations: APIOperations{opGetV2}},
+			},
+		},
+		{
+			name:  "no match",
+			query: OperationQuery{Kind: OperationKindDelete},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, queryResults(results, tt.query))
+		})
+	}
+
+	var buf bytes.Buffer
+	writeQueryText(&buf, queryResults(results, OperationQuery{ProviderNamespace: "Microsoft.Foo", Version: "2025-04-01"}))
+	require.Equal(t, `azurerm_foo
+  create:
+    GET /SUBSCRIPTIONS/{}/PROVIDERS/MICROSOFT.FOO/LOCATIONS/{}/OPERATIONSTATUSES/{} 2025-04-01
+    GET /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{} 2025-04-01
+    PUT /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{} 2025-04-01 (LRO)
+  read:
+    GET /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{} 2025-04-01
+`, buf.String())
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// RoleDefinition is an Azure RBAC custom role definition, in the form accepted by "az role definition create".
+type RoleDefinition struct {
+	Name             string   `json:"Name"`
+	IsCustom         bool     `json:"IsCustom"`
+	Description      string   `json:"Description"`
+	Actions          []string `json:"Actions"`
+	NotActions       []string `json:"NotActions"`
+	DataActions      []string `json:"DataActions"`
+	NotDataActions   []string `json:"NotDataActions"`
+	AssignableScopes []string `json:"AssignableScopes"`
+}
+
+// rbacAction maps an ARM operation to the Azure RBAC action, e.g. "microsoft.foo/foos/read", from its ARMPath. As RBAC
+// actions are case insensitive, while the ARMPath is in the casing of the SDK, the action is returned in lower case.
+func rbacAction(op APIOperation) (string, error) {
+	p := armPathOf(op)
+	if p.ProviderNamespace == "" {
+		return "", fmt.Errorf("not an ARM operation")
+	}
+
+	var verb string
+	switch op.Kind {
+	case OperationKindGet, OperationKindHead, OperationKindOptions:
+		verb = "read"
+	case OperationKindPut, OperationKindPatch:
+		verb = "write"
+	case OperationKindDelete:
+		verb = "delete"
+	case OperationKindPost:
+		verb = "action"
+	default:
+		return "", fmt.Errorf("unknown operation kind %q", op.Kind)
+	}
+
+	segs := append([]string{p.ProviderNamespace}, p.ResourceTypes.Values()...)
+	if p.Action != "" {
+		segs = append(segs, p.Action)
+	}
+	return strings.ToLower(strings.Join(append(segs, verb), "/")), nil
+}
+
+// armPathOf returns the ARMPath of the API operation. For the reports without the arm_path (e.g. generated by an older
+// version), it is parsed from the normalized API path. It returns a zero ARMPath for the non-ARM operations.
+func armPathOf(op APIOperation) ARMPath {
+	if op.Plane == PlaneData {
+		return ARMPath{}
+	}
+	if op.ARMPath.ProviderNamespace != "" {
+		return op.ARMPath
+	}
+	return parseARMPath(op.Path, op.Kind)
+}
+
+// rbacActions maps the API operations to a sorted and deduplicated list of Azure RBAC actions.
+// The non-ARM operations are skipped, e.g. the data-plane operations, which are authorized by the data actions that can't
+// be derived from the API path.
+func rbacActions(ops APIOperations) ([]string, error) {
+	actions := []string{}
+	for _, op := range ops {
+		if armPathOf(op).ProviderNamespace == "" {
+			continue
+		}
+		action, err := rbacAction(op)
+		if err != nil {
+			return nil, fmt.Errorf("mapping %s %s to RBAC action: %v", op.Kind, op.Path, err)
+		}
+		actions = append(actions, action)
+	}
+	slices.Sort(actions)
+	return slices.Compact(actions), nil
+}
+
+func runRBAC(args []string) error {
+	fs := flag.NewFlagSet("rbac", flag.ExitOnError)
+	flagOutputDir := fs.String("output-dir", ".", "The directory to write the role definition files")
+	flagName := fs.String("name", "aztfo", "The role name. It is used as the role name prefix unless -combine is specified")
+	flagScopes := fs.String("scopes", "/subscriptions/{subscriptionId}", "A comma separated assignable scopes of the role definitions")
+	flagResources := fs.String("resources", "", `A comma separated resource types to include. For data source, add the prefix "data.".`)
+	flagCombine := fs.Bool("combine", false, "Generate one role definition for all the (filtered) resources, instead of one per resource")
+	flagSplitByVerb := fs.Bool("split-by-verb", false, "Generate one role definition per verb (e.g. create/read/update/delete)")
+	fs.Usage = func() {
+		fmt.Println(`Usage: aztfo rbac [options] <report>
+
+Generates the least-privilege Azure RBAC custom role definitions from an aztfo report.
+
+Arguments:
+  - report
+	The aztfo output file, or "-" to read from the stdin.
+
+Options:`)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expect exactly one argument, got=%d", fs.NArg())
+	}
+
+	results, err := readResults(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *flagResources != "" {
+		ids := parseResourceIds(*flagResources)
+		results = slices.DeleteFunc(results, func(r Result) bool { return !slices.Contains(ids, r.Id) })
+	}
+
+	type roleInput struct {
+		fileName   string
+		roleName   string
+		desc       string
+		operations APIOperations
+	}
+	var inputs []roleInput
+
+	// group adds the role inputs of a set of results, under the specified name.
+	group := func(fileName, roleName, desc string, results Results) {
+		if !*flagSplitByVerb {
+			var ops APIOperations
+			for _, res := range results {
+				for _, vo := range res.VerbOperations() {
+					ops.Union(vo.Operations)
+				}
+			}
+			inputs = append(inputs, roleInput{fileName: fileName, roleName: roleName, desc: desc, operations: ops})
+			return
+		}
+		for i, vo := range (Result{}).VerbOperations() {
+			var ops APIOperations
+			for _, res := range results {
+				ops.Union(res.VerbOperations()[i].Operations)
+			}
+			if len(ops) == 0 {
+				continue
+			}
+			inputs = append(inputs, roleInput{
+				fileName:   fileName + "." + vo.Verb,
+				roleName:   fmt.Sprintf("%s (%s)", roleName, vo.Verb),
+				desc:       fmt.Sprintf("%s (%s)", desc, vo.Verb),
+				operations: ops,
+			})
+		}
+	}
+
+	if *flagCombine {
+		group(*flagName, *flagName, fmt.Sprintf("Permissions to manage %d Terraform resources", len(results)), results)
+	} else {
+		for _, res := range results {
+			group(res.Id.Address(), fmt.Sprintf("%s - %s", *flagName, res.Id.Address()), fmt.Sprintf("Permissions to manage %s", res.Id), Results{res})
+		}
+	}
+
+	if err := os.MkdirAll(*flagOutputDir, 0755); err != nil {
+		return fmt.Errorf("creating the output directory: %v", err)
+	}
+	for _, input := range inputs {
+		actions, err := rbacActions(input.operations)
+		if err != nil {
+			return fmt.Errorf("%s: %v", input.roleName, err)
+		}
+		if n := len(slices.DeleteFunc(slices.Clone(input.operations), func(op APIOperation) bool { return op.Plane != PlaneData })); n != 0 {
+			fmt.Fprintf(os.Stderr, "WARNING: %s: %d data-plane operations are not mapped to the DataActions\n", input.roleName, n)
+		}
+		if n := len(slices.DeleteFunc(slices.Clone(input.operations), func(op APIOperation) bool {
+			return op.Plane == PlaneData || armPathOf(op).ProviderNamespace != ""
+		})); n != 0 {
+			fmt.Fprintf(os.Stderr, "WARNING: %s: %d non-ARM operations (e.g. Microsoft Graph) are not mapped to the Actions\n", input.roleName, n)
+		}
+		def := RoleDefinition{
+			Name:             input.roleName,
+			IsCustom:         true,
+			Description:      input.desc,
+			Actions:          actions,
+			NotActions:       []string{},
+			DataActions:      []string{},
+			NotDataActions:   []string{},
+			AssignableScopes: strings.Split(*flagScopes, ","),
+		}
+		b, err := json.MarshalIndent(def, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal the role definition of %s: %v", input.roleName, err)
+		}
+		if err := os.WriteFile(filepath.Join(*flagOutputDir, input.fileName+".json"), b, 0644); err != nil {
+			return fmt.Errorf("writing the role definition of %s: %v", input.roleName, err)
+		}
+	}
+	return nil
+}
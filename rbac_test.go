@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBACAction(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name   string
+		op     APIOperation
+		expect string
+	}{
+		{
+			name:   "read resource",
+			op:     APIOperation{Kind: OperationKindGet, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"},
+			expect: "microsoft.foo/foos/read",
+		},
+		{
+			name:   "write nested resource",
+			op:     APIOperation{Kind: OperationKindPut, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/BARS/{}"},
+			expect: "microsoft.foo/foos/bars/write",
+		},
+		{
+			name:   "patch resource",
+			op:     APIOperation{Kind: OperationKindPatch, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"},
+			expect: "microsoft.foo/foos/write",
+		},
+		{
+			name:   "delete resource group",
+			op:     APIOperation{Kind: OperationKindDelete, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}"},
+			expect: "microsoft.resources/subscriptions/resourcegroups/delete",
+		},
+		{
+			name:   "list collection",
+			op:     APIOperation{Kind: OperationKindGet, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/RESOURCES"},
+			expect: "microsoft.resources/subscriptions/resourcegroups/resources/read",
+		},
+		{
+			name:   "post action",
+			op:     APIOperation{Kind: OperationKindPost, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/UNLOCKDELETE"},
+			expect: "microsoft.foo/foos/unlockdelete/action",
+		},
+		{
+			name:   "extension resource",
+			op:     APIOperation{Kind: OperationKindPut, Path: "/{}/PROVIDERS/MICROSOFT.AUTHORIZATION/ROLEASSIGNMENTS/{}"},
+			expect: "microsoft.authorization/roleassignments/write",
+		},
+		{
+			name:   "subscription",
+			op:     APIOperation{Kind: OperationKindGet, Path: "/SUBSCRIPTIONS/{}"},
+			expect: "microsoft.resources/subscriptions/read",
+		},
+		{
+			name:   "from ARMPath",
+			op:     APIOperation{Kind: OperationKindPost, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/LISTKEYS", ARMPath: parseARMPath("/subscriptions/{}/resourceGroups/{}/providers/Microsoft.Foo/foos/{}/listKeys", OperationKindPost)},
+			expect: "microsoft.foo/foos/listkeys/action",
+		},
+		{
+			name:   "resource group from ARMPath",
+			op:     APIOperation{Kind: OperationKindDelete, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}", ARMPath: parseARMPath("/subscriptions/{}/resourceGroups/{}", OperationKindDelete)},
+			expect: "microsoft.resources/subscriptions/resourcegroups/delete",
+		},
+		{
+			name:   "singleton resource",
+			op:     APIOperation{Kind: OperationKindGet, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.WEB/SITES/{}/CONFIG/WEB"},
+			expect: "microsoft.web/sites/config/read",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			action, err := rbacAction(c.op)
+			require.NoError(t, err)
+			require.Equal(t, c.expect, action)
+		})
+	}
+}
+
+func TestRBACActions(t *testing.T) {
+	t.Parallel()
+	actions, err := rbacActions(APIOperations{
+		{Kind: OperationKindGet, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"},
+		{Kind: OperationKindPut, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"},
+		{Kind: OperationKindGet, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}", Version: "2025-04-01"},
+		// The data-plane operations are skipped.
+		{Kind: OperationKindGet, Path: "/SECRETS/{}/{}", Plane: PlaneData, Endpoint: "https://{}.vault.azure.net"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"microsoft.foo/foos/read", "microsoft.foo/foos/write"}, actions)
+
+	// The non-ARM operations are skipped.
+	actions, err = rbacActions(APIOperations{{Kind: OperationKindGet, Path: "/APPLICATIONS/{}"}})
+	require.NoError(t, err)
+	require.Empty(t, actions)
+	_, err = rbacAction(APIOperation{Kind: OperationKindGet, Path: "/APPLICATIONS/{}"})
+	require.Error(t, err)
+}
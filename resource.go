@@ -1,12 +1,17 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/ast"
+	"go/constant"
+	"go/token"
 	"go/types"
 	"log"
 	"maps"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/magodo/aztfo/typeutils"
@@ -20,23 +25,148 @@ type ResourceFuncs struct {
 	R *ssa.Function
 	U *ssa.Function
 	D *ssa.Function
+
+	// Import, Plan and Migrate are the functions invoked during the "terraform import" (the importer), "terraform plan"
+	// (the customize diff) and the state migration (the state upgraders) respectively.
+	// There can be more than one function for each of them, e.g. one per state upgrader.
+	Import  []*ssa.Function
+	Plan    []*ssa.Function
+	Migrate []*ssa.Function
+
+	// Open, Renew and Close are the functions of the ephemeral resources, which have no CRUD functions.
+	Open  *ssa.Function
+	Renew *ssa.Function
+	Close *ssa.Function
+
+	// List is the function of the list resources (for "terraform query"), and Invoke is the function of the actions.
+	List   *ssa.Function
+	Invoke *ssa.Function
+}
+
+// ResourceKind is the kind of a Terraform resource type.
+type ResourceKind int
+
+const (
+	ResourceKindResource ResourceKind = iota
+	ResourceKindDataSource
+	ResourceKindEphemeral
+	ResourceKindList
+	ResourceKindAction
+)
+
+func (k ResourceKind) String() string {
+	switch k {
+	case ResourceKindResource:
+		return "resource"
+	case ResourceKindDataSource:
+		return "data_source"
+	case ResourceKindEphemeral:
+		return "ephemeral"
+	case ResourceKindList:
+		return "list"
+	case ResourceKindAction:
+		return "action"
+	default:
+		return fmt.Sprintf("ResourceKind(%d)", int(k))
+	}
+}
+
+func (k ResourceKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *ResourceKind) UnmarshalText(b []byte) error {
+	for _, kind := range []ResourceKind{ResourceKindResource, ResourceKindDataSource, ResourceKindEphemeral, ResourceKindList, ResourceKindAction} {
+		if kind.String() == string(b) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown resource kind %q", string(b))
 }
 
 type ResourceId struct {
-	Name         string `json:"name"`
-	IsDataSource bool   `json:"is_data_source"`
+	Name string
+	Kind ResourceKind
+}
+
+// resourceIdJSON is the JSON form of the ResourceId. The "is_data_source" is kept for the compatibility of the consumers,
+// and is used to determine the kind when reading the reports that have no "kind".
+type resourceIdJSON struct {
+	Name         string        `json:"name"`
+	Kind         *ResourceKind `json:"kind,omitempty"`
+	IsDataSource bool          `json:"is_data_source"`
+}
+
+func (id ResourceId) MarshalJSON() ([]byte, error) {
+	return json.Marshal(resourceIdJSON{Name: id.Name, Kind: &id.Kind, IsDataSource: id.Kind == ResourceKindDataSource})
+}
+
+func (id *ResourceId) UnmarshalJSON(b []byte) error {
+	var v resourceIdJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	id.Name = v.Name
+	switch {
+	case v.Kind != nil:
+		id.Kind = *v.Kind
+	case v.IsDataSource:
+		id.Kind = ResourceKindDataSource
+	default:
+		id.Kind = ResourceKindResource
+	}
+	return nil
 }
 
 func (id ResourceId) String() string {
 	ret := id.Name
-	if id.IsDataSource {
+	switch id.Kind {
+	case ResourceKindDataSource:
 		ret += " (DS)"
+	case ResourceKindEphemeral:
+		ret += " (EPH)"
+	case ResourceKindList:
+		ret += " (LIST)"
+	case ResourceKindAction:
+		ret += " (ACT)"
 	}
 	return ret
 }
 
-// findResources finds terraform resource (untyped+typed) information among the specified packages.
-func findResources(pkgs []Package) (ResourceInfos, error) {
+// resourceAddressPrefixes are the address prefixes of the resource kinds, as in the Terraform configuration.
+var resourceAddressPrefixes = map[ResourceKind]string{
+	ResourceKindDataSource: "data.",
+	ResourceKindEphemeral:  "ephemeral.",
+	ResourceKindList:       "list.",
+	ResourceKindAction:     "action.",
+}
+
+// Address returns the address of the resource type as in the Terraform configuration, e.g. "data.azurerm_resource_group"
+// or "ephemeral.azurerm_key_vault_secret".
+func (id ResourceId) Address() string {
+	return resourceAddressPrefixes[id.Kind] + id.Name
+}
+
+// parseResourceIds parses a comma separated resource addresses (see ResourceId.Address) to the resource ids.
+func parseResourceIds(s string) []ResourceId {
+	var ids []ResourceId
+	for res := range strings.SplitSeq(s, ",") {
+		id := ResourceId{Name: res}
+		for kind, prefix := range resourceAddressPrefixes {
+			if name, ok := strings.CutPrefix(res, prefix); ok {
+				id = ResourceId{Name: name, Kind: kind}
+				break
+			}
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// findResources finds terraform resource (untyped+typed+framework) information among the specified packages.
+// In the keep-going mode (i.e. diags is not nil), the resources that failed to be analyzed are recorded as diagnostics and skipped.
+func findResources(pkgs []Package, rules ResourceRules, diags *Diagnostics) (ResourceInfos, error) {
 	log.Println("Find resources: begin")
 	defer log.Println("Find resources: end")
 
@@ -44,7 +174,7 @@ func findResources(pkgs []Package) (ResourceInfos, error) {
 
 	for _, pkg := range pkgs {
 		var regFound bool
-		for _, regName := range []string{"Registration", "autoRegistration"} {
+		for _, regName := range rules.Registrations {
 			reg := pkg.pkg.Types.Scope().Lookup(regName)
 			if reg == nil {
 				continue
@@ -56,6 +186,11 @@ func findResources(pkgs []Package) (ResourceInfos, error) {
 				methodSupportedResources   *types.Func
 				methodDataSources          *types.Func
 				methodResources            *types.Func
+				methodFrameworkDataSources *types.Func
+				methodFrameworkResources   *types.Func
+				methodEphemeralResources   *types.Func
+				methodListResources        *types.Func
+				methodActions              *types.Func
 			)
 			for method := range reg.Type().(*types.Named).Methods() {
 				switch method.Name() {
@@ -67,50 +202,83 @@ func findResources(pkgs []Package) (ResourceInfos, error) {
 					methodDataSources = method
 				case "Resources":
 					methodResources = method
+				case "FrameworkDataSources":
+					methodFrameworkDataSources = method
+				case "FrameworkResources":
+					methodFrameworkResources = method
+				case "EphemeralResources":
+					methodEphemeralResources = method
+				case "ListResources":
+					methodListResources = method
+				case "Actions":
+					methodActions = method
 				}
 			}
 
-			// fmt.Println(
-			// 	methodSupportedDataSources.Name(),
-			// 	methodSupportedResources.Name(),
-			// 	methodDataSources.Name(),
-			// 	methodResources.Name(),
-			// )
-
-			theInfos, err := findUnTypedResource(pkg, methodSupportedDataSources, true)
-			if err != nil {
-				return nil, fmt.Errorf("failed to find untyped data resources: %v", err)
-			}
-			maps.Copy(infos, theInfos)
-
-			theInfos, err = findUnTypedResource(pkg, methodSupportedResources, false)
-			if err != nil {
-				return nil, fmt.Errorf("failed to find untyped resources: %v", err)
-			}
-			maps.Copy(infos, theInfos)
-
-			theInfos, err = findTypedResource(pkg, methodDataSources, true)
-			if err != nil {
-				return nil, fmt.Errorf("failed to find typed data resources: %v", err)
-			}
-			maps.Copy(infos, theInfos)
-
-			theInfos, err = findTypedResource(pkg, methodResources, false)
-			if err != nil {
-				return nil, fmt.Errorf("failed to find typed resources: %v", err)
+			for _, finder := range []struct {
+				desc   string
+				f      func(Package, *types.Func, ResourceKind, ResourceRules, *Diagnostics) (ResourceInfos, error)
+				method *types.Func
+				kind   ResourceKind
+			}{
+				{desc: "untyped data resources", f: findUnTypedResource, method: methodSupportedDataSources, kind: ResourceKindDataSource},
+				{desc: "untyped resources", f: findUnTypedResource, method: methodSupportedResources, kind: ResourceKindResource},
+				{desc: "typed data resources", f: findTypedResource, method: methodDataSources, kind: ResourceKindDataSource},
+				{desc: "typed resources", f: findTypedResource, method: methodResources, kind: ResourceKindResource},
+				{desc: "framework data resources", f: findFrameworkResource, method: methodFrameworkDataSources, kind: ResourceKindDataSource},
+				{desc: "framework resources", f: findFrameworkResource, method: methodFrameworkResources, kind: ResourceKindResource},
+				{desc: "ephemeral resources", f: findFrameworkResource, method: methodEphemeralResources, kind: ResourceKindEphemeral},
+				{desc: "list resources", f: findFrameworkResource, method: methodListResources, kind: ResourceKindList},
+				{desc: "actions", f: findFrameworkResource, method: methodActions, kind: ResourceKindAction},
+			} {
+				theInfos, err := finder.f(pkg, finder.method, finder.kind, rules, diags)
+				if err != nil {
+					if err := diags.Handle(Diagnostic{
+						Position: position(pkg.pkg.Fset, finder.method.Pos()),
+						Reason:   fmt.Sprintf("failed to find %s: %v", finder.desc, err),
+					}); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				maps.Copy(infos, theInfos)
 			}
-			maps.Copy(infos, theInfos)
 		}
 
 		if !regFound {
-			return nil, fmt.Errorf(`"Registration" not found at package %q`, pkg.pkg.PkgPath)
+			if err := diags.Handle(Diagnostic{Reason: fmt.Sprintf(`registration (%s) not found at package %q`, strings.Join(rules.Registrations, ", "), pkg.pkg.PkgPath)}); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	return infos, nil
 }
 
-func findUnTypedResource(pkg Package, f *types.Func, isDataSource bool) (ResourceInfos, error) {
+// untypedResourceInitFunc parses the registration of an untyped resource, e.g. `"azurerm_resource_group": resourceResourceGroup()`,
+// where the key is the resource name, and the value is a call to the resource init function.
+func untypedResourceInitFunc(pkg Package, key, value ast.Expr) (string, *types.Func, error) {
+	lit, ok := key.(*ast.BasicLit)
+	if !ok {
+		return "", nil, fmt.Errorf("unexpected resource name expression type: %T", key)
+	}
+	name, _ := strconv.Unquote(lit.Value)
+	call, ok := value.(*ast.CallExpr)
+	if !ok {
+		return name, nil, fmt.Errorf("unexpected registration expression type: %T", value)
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok {
+		return name, nil, fmt.Errorf("unexpected registration function expression type: %T", call.Fun)
+	}
+	f, ok := pkg.pkg.TypesInfo.ObjectOf(ident).(*types.Func)
+	if !ok {
+		return name, nil, fmt.Errorf("registration function object of %q not found", name)
+	}
+	return name, f, nil
+}
+
+func findUnTypedResource(pkg Package, f *types.Func, kind ResourceKind, rules ResourceRules, diags *Diagnostics) (ResourceInfos, error) {
 	if f == nil {
 		return nil, nil
 	}
@@ -121,6 +289,17 @@ func findUnTypedResource(pkg Package, f *types.Func, isDataSource bool) (Resourc
 
 	resourceInitFuncs := map[ResourceId]*types.Func{}
 
+	// addInitFunc records the resource init function of a registration, or handles the diagnostic if failed.
+	addInitFunc := func(key, value ast.Expr) error {
+		name, f, ferr := untypedResourceInitFunc(pkg, key, value)
+		if ferr != nil {
+			rid := ResourceId{Name: name, Kind: kind}
+			return diags.Handle(Diagnostic{Resource: rid.Address(), Position: position(pkg.pkg.Fset, value.Pos()), Reason: ferr.Error()})
+		}
+		resourceInitFuncs[ResourceId{Name: name, Kind: kind}] = f
+		return nil
+	}
+
 	// Mostly this function contains only a composite literal of resource map, e.g.
 	//
 	// 	resources := map[string]*pluginsdk.Resource{
@@ -155,7 +334,7 @@ func findUnTypedResource(pkg Package, f *types.Func, isDataSource bool) (Resourc
 		if !ok {
 			return true
 		}
-		if mtvalselx.Name != "pluginsdk" {
+		if mtvalselx.Name != rules.UntypedSDKPackage {
 			return true
 		}
 		if mtvalsel.Sel.Name != "Resource" {
@@ -163,14 +342,18 @@ func findUnTypedResource(pkg Package, f *types.Func, isDataSource bool) (Resourc
 		}
 
 		for _, e := range complit.Elts {
-			kv := e.(*ast.KeyValueExpr)
-			name, _ := strconv.Unquote(kv.Key.(*ast.BasicLit).Value)
-			f := pkg.pkg.TypesInfo.ObjectOf(kv.Value.(*ast.CallExpr).Fun.(*ast.Ident))
-			if f == nil {
-				err = multierror.Append(err, fmt.Errorf("registration function object of %q not found", name))
+			kv, ok := e.(*ast.KeyValueExpr)
+			if !ok {
+				if herr := diags.Handle(Diagnostic{Position: position(pkg.pkg.Fset, e.Pos()), Reason: fmt.Sprintf("unexpected resource map element type: %T", e)}); herr != nil {
+					err = multierror.Append(err, herr)
+					return false
+				}
+				continue
+			}
+			if herr := addInitFunc(kv.Key, kv.Value); herr != nil {
+				err = multierror.Append(err, herr)
 				return false
 			}
-			resourceInitFuncs[ResourceId{Name: name, IsDataSource: isDataSource}] = f.(*types.Func)
 		}
 
 		return false
@@ -196,18 +379,12 @@ func findUnTypedResource(pkg Package, f *types.Func, isDataSource bool) (Resourc
 		if !ok {
 			return true
 		}
-		idx, ok := idxExpr.Index.(*ast.BasicLit)
-		if !ok {
+		if _, ok := idxExpr.Index.(*ast.BasicLit); !ok {
 			return true
 		}
-		name, _ := strconv.Unquote(idx.Value)
-		f := pkg.pkg.TypesInfo.ObjectOf(assign.Rhs[0].(*ast.CallExpr).Fun.(*ast.Ident))
-		if f == nil {
-			err = multierror.Append(err, fmt.Errorf("registration function object of %q not found", name))
-			return false
+		if herr := addInitFunc(idxExpr.Index, assign.Rhs[0]); herr != nil {
+			err = multierror.Append(err, herr)
 		}
-		resourceInitFuncs[ResourceId{Name: name, IsDataSource: isDataSource}] = f.(*types.Func)
-
 		return false
 	})
 	if err != nil {
@@ -217,80 +394,125 @@ func findUnTypedResource(pkg Package, f *types.Func, isDataSource bool) (Resourc
 	// Find the CRUD functions from the resource init function
 	infos := ResourceInfos{}
 	for rid, initFunc := range resourceInitFuncs {
-		fdecl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg.pkg, initFunc)
+		funcs, err := untypedResourceFuncs(pkg, initFunc, rules.UntypedSDKPackage)
 		if err != nil {
-			return nil, fmt.Errorf("lookup function declaration from object of %q failed: %v", initFunc.Id(), err)
+			if err := diags.Handle(Diagnostic{Resource: rid.Address(), Position: position(pkg.pkg.Fset, initFunc.Pos()), Reason: err.Error()}); err != nil {
+				return nil, err
+			}
+			continue
 		}
+		infos[rid] = funcs
+	}
 
-		funcs := ResourceFuncs{}
-		ast.Inspect(fdecl.Body, func(n ast.Node) bool {
-			complit, ok := n.(*ast.CompositeLit)
+	return infos, nil
+}
+
+// untypedResourceFuncs finds the CRUD functions from the untyped resource init function.
+func untypedResourceFuncs(pkg Package, initFunc *types.Func, sdkPkgName string) (ResourceFuncs, error) {
+	fdecl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg.pkg, initFunc)
+	if err != nil {
+		return ResourceFuncs{}, fmt.Errorf("lookup function declaration from object of %q failed: %v", initFunc.Id(), err)
+	}
+
+	ssaFunc := func(v ast.Expr) (*ssa.Function, error) {
+		switch v := v.(type) {
+		case *ast.Ident:
+			return typeutils.SSAFunction(pkg.ssa, v.Name), nil
+		case *ast.CallExpr:
+			// E.g. in "resourceHDInsightKafkaCluster":
+			//
+			// Update: hdinsightClusterUpdate("Kafka", resourceHDInsightKafkaClusterRead),
+			//
+			// Need to follow the call.
+			ident, ok := v.Fun.(*ast.Ident)
 			if !ok {
-				return true
+				return nil, fmt.Errorf("unexpected resource function call expression type at %s: %T", position(pkg.pkg.Fset, v.Pos()), v.Fun)
+			}
+			ff, err := findResourceFunc(pkg.ssa.Prog, pkg, typeutils.SSAFunction(pkg.ssa, ident.Name))
+			if err != nil {
+				return nil, fmt.Errorf("failed to follow untyped resource func at %s: %v", position(pkg.pkg.Fset, v.Pos()), err)
 			}
-			sl, ok := complit.Type.(*ast.SelectorExpr)
+			return ff, nil
+		default:
+			return nil, fmt.Errorf("unexpected resource function expression type at %s: %T", position(pkg.pkg.Fset, v.Pos()), v)
+		}
+	}
+
+	funcs := ResourceFuncs{}
+	ast.Inspect(fdecl.Body, func(n ast.Node) bool {
+		complit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		sl, ok := complit.Type.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		slx, ok := sl.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if slx.Name != sdkPkgName {
+			return true
+		}
+		if sl.Sel.Name != "Resource" {
+			return true
+		}
+		for _, e := range complit.Elts {
+			kv, ok := e.(*ast.KeyValueExpr)
 			if !ok {
-				return true
+				continue
 			}
-			slx, ok := sl.X.(*ast.Ident)
+			k, ok := kv.Key.(*ast.Ident)
 			if !ok {
-				return true
+				continue
 			}
-			if slx.Name != "pluginsdk" {
-				return true
+
+			var (
+				target      **ssa.Function
+				multiTarget *[]*ssa.Function
+			)
+			switch k.Name {
+			case "Create":
+				target = &funcs.C
+			case "Read":
+				target = &funcs.R
+			case "Update":
+				target = &funcs.U
+			case "Delete":
+				target = &funcs.D
+			case "Importer":
+				multiTarget = &funcs.Import
+			case "CustomizeDiff":
+				multiTarget = &funcs.Plan
+			case "StateUpgraders":
+				multiTarget = &funcs.Migrate
+			default:
+				continue
 			}
-			if sl.Sel.Name != "Resource" {
-				return true
+			if multiTarget != nil {
+				*multiTarget = referencedFuncs(pkg, typeutils.SSAFunction(pkg.ssa, initFunc.Name()), kv.Value)
+				continue
 			}
-			for _, e := range complit.Elts {
-				kv := e.(*ast.KeyValueExpr)
-				k, ok := kv.Key.(*ast.Ident)
-				if !ok {
-					continue
-				}
-
-				ssaFunc := func(v ast.Expr) *ssa.Function {
-					switch v := v.(type) {
-					case *ast.Ident:
-						return typeutils.SSAFunction(pkg.ssa, v.Name)
-					case *ast.CallExpr:
-						// E.g. in "resourceHDInsightKafkaCluster":
-						//
-						// Update: hdinsightClusterUpdate("Kafka", resourceHDInsightKafkaClusterRead),
-						//
-						// Need to follow the call.
-						ff, err := findResourceFunc(pkg.ssa.Prog, pkg, typeutils.SSAFunction(pkg.ssa, v.Fun.(*ast.Ident).Name))
-						if err != nil {
-							panic(fmt.Sprintf("failed to follow untyped resource func for %q: %v", rid, err))
-						}
-						return ff
-					default:
-						panic("unreachable")
-					}
-				}
-				switch k.Name {
-				case "Create":
-					funcs.C = ssaFunc(kv.Value)
-				case "Read":
-					funcs.R = ssaFunc(kv.Value)
-				case "Update":
-					funcs.U = ssaFunc(kv.Value)
-				case "Delete":
-					funcs.D = ssaFunc(kv.Value)
-				}
+			f, ferr := ssaFunc(kv.Value)
+			if ferr != nil {
+				err = multierror.Append(err, ferr)
+				continue
 			}
-			return false
-
-			// TODO: Consider forms other than composite literal
-		})
+			*target = f
+		}
+		return false
 
-		infos[rid] = funcs
+		// TODO: Consider forms other than composite literal
+	})
+	if err != nil {
+		return ResourceFuncs{}, err
 	}
 
-	return infos, nil
+	return funcs, nil
 }
 
-func findTypedResource(pkg Package, f *types.Func, isDataSource bool) (ResourceInfos, error) {
+func findTypedResource(pkg Package, f *types.Func, kind ResourceKind, rules ResourceRules, diags *Diagnostics) (ResourceInfos, error) {
 	if f == nil {
 		return nil, nil
 	}
@@ -325,10 +547,10 @@ func findTypedResource(pkg Package, f *types.Func, isDataSource bool) (ResourceI
 		if !ok {
 			return true
 		}
-		if ateselx.Name != "sdk" {
+		if ateselx.Name != rules.TypedSDKPackage {
 			return true
 		}
-		if isDataSource {
+		if kind == ResourceKindDataSource {
 			if ate.Sel.Name != "DataSource" {
 				return true
 			}
@@ -339,8 +561,14 @@ func findTypedResource(pkg Package, f *types.Func, isDataSource bool) (ResourceI
 		}
 
 		for _, e := range complit.Elts {
-			complit := e.(*ast.CompositeLit)
-			t := pkg.pkg.TypesInfo.ObjectOf(complit.Type.(*ast.Ident)).Type().(*types.Named)
+			t, ok := pkg.pkg.TypesInfo.TypeOf(e).(*types.Named)
+			if !ok {
+				if herr := diags.Handle(Diagnostic{Position: position(pkg.pkg.Fset, e.Pos()), Reason: fmt.Sprintf("unexpected typed resource type: %s", pkg.pkg.TypesInfo.TypeOf(e))}); herr != nil {
+					err = multierror.Append(err, herr)
+					return false
+				}
+				continue
+			}
 			resourceTypes = append(resourceTypes, t)
 		}
 
@@ -348,65 +576,231 @@ func findTypedResource(pkg Package, f *types.Func, isDataSource bool) (ResourceI
 
 		return false
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	infos := ResourceInfos{}
 	for _, rt := range resourceTypes {
-		// Retrieve the resource type
-		resourceTypeFunc := typeutils.NamedTypeMethodByName(rt, "ResourceType")
-		resourceTypeFuncDecl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg.pkg, resourceTypeFunc)
+		rid, funcs, err := typedResourceFuncs(pkg, rt, kind)
 		if err != nil {
-			return nil, fmt.Errorf("lookup function declaration from object of %q failed: %v", resourceTypeFunc.Id(), err)
+			resource := rt.Obj().Name()
+			if rid.Name != "" {
+				resource = rid.Address()
+			}
+			if err := diags.Handle(Diagnostic{Resource: resource, Position: position(pkg.pkg.Fset, rt.Obj().Pos()), Reason: err.Error()}); err != nil {
+				return nil, err
+			}
+			continue
 		}
+		infos[rid] = funcs
+	}
 
-		var name string
-		switch res := resourceTypeFuncDecl.Body.List[0].(*ast.ReturnStmt).Results[0].(type) {
-		case *ast.BasicLit:
-			name, _ = strconv.Unquote(res.Value)
-		case *ast.Ident:
-			name = res.Obj.Decl.(*ast.ValueSpec).Values[0].(*ast.BasicLit).Value
-			name, _ = strconv.Unquote(name)
-		default:
-			panic("unreachable")
+	return infos, nil
+}
+
+// typedResourceFuncs finds the resource id and the CRUD functions of the typed resource.
+func typedResourceFuncs(pkg Package, rt *types.Named, kind ResourceKind) (ResourceId, ResourceFuncs, error) {
+	// Retrieve the resource type
+	resourceTypeFunc := typeutils.NamedTypeMethodByName(rt, "ResourceType")
+	if resourceTypeFunc == nil {
+		return ResourceId{}, ResourceFuncs{}, fmt.Errorf("method ResourceType of %q not found", rt.Obj().Name())
+	}
+	resourceTypeFuncDecl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg.pkg, resourceTypeFunc)
+	if err != nil {
+		return ResourceId{}, ResourceFuncs{}, fmt.Errorf("lookup function declaration from object of %q failed: %v", resourceTypeFunc.Id(), err)
+	}
+
+	var name string
+	if len(resourceTypeFuncDecl.Body.List) == 0 {
+		return ResourceId{}, ResourceFuncs{}, fmt.Errorf("empty ResourceType implementation of %q", rt.Obj().Name())
+	}
+	if ret, ok := resourceTypeFuncDecl.Body.List[0].(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+		if tv, ok := pkg.pkg.TypesInfo.Types[ret.Results[0]]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			name = constant.StringVal(tv.Value)
+		}
+	}
+	if name == "" {
+		return ResourceId{}, ResourceFuncs{}, fmt.Errorf("unexpected ResourceType implementation of %q, expect to return a constant string", rt.Obj().Name())
+	}
+	rid := ResourceId{Name: name, Kind: kind}
+
+	// Retrieve the methods
+	prog := pkg.ssa.Prog
+	funcs := ResourceFuncs{}
+	for _, methodName := range []string{"Create", "Update", "Read", "Delete"} {
+		sel := prog.MethodSets.MethodSet(rt).Lookup(pkg.pkg.Types, methodName)
+		if sel == nil {
+			continue
 		}
 
-		// Retrieve the methods
-		prog := pkg.ssa.Prog
-		funcs := ResourceFuncs{}
-		for _, methodName := range []string{"Create", "Update", "Read", "Delete"} {
-			sel := prog.MethodSets.MethodSet(rt).Lookup(pkg.pkg.Types, methodName)
-			if sel == nil {
-				continue
-			}
+		ssaf := prog.MethodValue(sel)
+		if ssaf == nil {
+			return rid, ResourceFuncs{}, fmt.Errorf("failed to find the ssa function determined by %q", sel.String())
+		}
 
-			ssaf := prog.MethodValue(sel)
-			if ssaf == nil {
-				return nil, fmt.Errorf("failed to find the ssa function determined by %q", sel.String())
-			}
+		f, err := findResourceFunc(prog, pkg, ssaf)
+		if err != nil {
+			return rid, ResourceFuncs{}, err
+		}
+		if f == nil {
+			continue
+		}
 
-			f, err := findResourceFunc(prog, pkg, ssaf)
-			if err != nil {
-				return nil, err
+		switch methodName {
+		case "Create":
+			funcs.C = f
+		case "Update":
+			funcs.U = f
+		case "Read":
+			funcs.R = f
+		case "Delete":
+			funcs.D = f
+		}
+	}
+
+	// Retrieve the methods of the optional interfaces, i.e. sdk.ResourceWithCustomImporter and sdk.ResourceWithCustomizeDiff
+	for _, methodName := range []string{"CustomImporter", "CustomizeDiff"} {
+		sel := prog.MethodSets.MethodSet(rt).Lookup(pkg.pkg.Types, methodName)
+		if sel == nil {
+			continue
+		}
+		ssaf := prog.MethodValue(sel)
+		if ssaf == nil {
+			return rid, ResourceFuncs{}, fmt.Errorf("failed to find the ssa function determined by %q", sel.String())
+		}
+		f, err := findResourceFunc(prog, pkg, ssaf)
+		if err != nil {
+			return rid, ResourceFuncs{}, err
+		}
+		if f == nil {
+			continue
+		}
+		switch methodName {
+		case "CustomImporter":
+			funcs.Import = append(funcs.Import, f)
+		case "CustomizeDiff":
+			funcs.Plan = append(funcs.Plan, f)
+		}
+	}
+
+	// Retrieve the state upgraders of the sdk.ResourceWithStateMigration, e.g.
+	//
+	//	func (r FooResource) StateUpgraders() sdk.StateUpgradeData {
+	//		return sdk.StateUpgradeData{
+	//			SchemaVersion: 1,
+	//			Upgraders: map[int]pluginsdk.StateUpgrade{
+	//				0: migration.FooV0ToV1{},
+	//			},
+	//		}
+	//	}
+	if sel := prog.MethodSets.MethodSet(rt).Lookup(pkg.pkg.Types, "StateUpgraders"); sel != nil {
+		ssaf := prog.MethodValue(sel)
+		if ssaf == nil {
+			return rid, ResourceFuncs{}, fmt.Errorf("failed to find the ssa function determined by %q", sel.String())
+		}
+		fdecl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg.pkg, sel.Obj().(*types.Func))
+		if err != nil {
+			return rid, ResourceFuncs{}, fmt.Errorf("lookup function declaration from object of %q failed: %v", sel.Obj().Id(), err)
+		}
+		funcs.Migrate = referencedFuncs(pkg, ssaf, fdecl.Body)
+	}
+
+	return rid, funcs, nil
+}
+
+// referencedFuncs returns the functions referenced in the AST node (e.g. the Importer of an untyped resource), which is
+// enclosed by the function "enclosing". These include:
+//
+//   - The function literals, e.g. pluginsdk.ImporterValidatingResourceId(func(id string) error {...})
+//   - The functions and methods referenced by name as values, e.g. pluginsdk.CustomizeDiffShim(fooCustomizeDiff)
+//   - The functions called, only if they are defined in the service package (or its sub-packages), e.g. fooImporter()
+//   - The anonymous functions returned by the UpgradeFunc method of the state upgraders, e.g. migration.FooV0ToV1{}
+//
+// As these functions are mostly called dynamically (e.g. by the plugin SDK), they are regarded as the entries of the analysis.
+// The helpers called out of the service package (e.g. pluginsdk.ImporterValidatingResourceId) are not, as their reachable
+// functions are not specific to the resource.
+func referencedFuncs(pkg Package, enclosing *ssa.Function, node ast.Node) []*ssa.Function {
+	prog := pkg.ssa.Prog
+	var funcs []*ssa.Function
+	add := func(f *ssa.Function) {
+		if f != nil && !slices.Contains(funcs, f) {
+			funcs = append(funcs, f)
+		}
+	}
+	// The identifiers of the called functions.
+	callees := map[*ast.Ident]bool{}
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			switch fun := n.Fun.(type) {
+			case *ast.Ident:
+				callees[fun] = true
+			case *ast.SelectorExpr:
+				callees[fun.Sel] = true
+			}
+		case *ast.FuncLit:
+			if enclosing != nil {
+				add(anonFuncAt(enclosing, n.Type.Func))
+			}
+			return false
+		case *ast.Ident:
+			f, ok := pkg.pkg.TypesInfo.Uses[n].(*types.Func)
+			if !ok {
+				return true
+			}
+			if sig, ok := f.Type().(*types.Signature); !ok || sig.TypeParams() != nil || sig.RecvTypeParams() != nil {
+				return true
+			}
+			if recv := f.Type().(*types.Signature).Recv(); recv != nil && types.IsInterface(recv.Type()) {
+				return true
 			}
+			if callees[n] && !isSubPackage(f.Pkg(), pkg.pkg.PkgPath) {
+				return true
+			}
+			add(prog.FuncValue(f))
+		case *ast.CompositeLit:
+			named, ok := pkg.pkg.TypesInfo.TypeOf(n).(*types.Named)
+			if !ok || named.TypeParams() != nil {
+				return true
+			}
+			sel := prog.MethodSets.MethodSet(named).Lookup(named.Obj().Pkg(), "UpgradeFunc")
+			if sel == nil {
+				return true
+			}
+			f := prog.MethodValue(sel)
 			if f == nil {
-				continue
+				return true
 			}
-
-			switch methodName {
-			case "Create":
-				funcs.C = f
-			case "Update":
-				funcs.U = f
-			case "Read":
-				funcs.R = f
-			case "Delete":
-				funcs.D = f
+			if len(f.AnonFuncs) == 0 {
+				add(f)
+			}
+			for _, af := range f.AnonFuncs {
+				add(af)
 			}
+			return false
 		}
+		return true
+	})
+	return funcs
+}
 
-		infos[ResourceId{Name: name, IsDataSource: isDataSource}] = funcs
-	}
+// isSubPackage tells whether the package is the package of the path, or is nested under it.
+func isSubPackage(pkg *types.Package, path string) bool {
+	return pkg != nil && (pkg.Path() == path || strings.HasPrefix(pkg.Path(), path+"/"))
+}
 
-	return infos, nil
+// anonFuncAt returns the anonymous function (recursively) enclosed by the function f, that is defined at the position.
+func anonFuncAt(f *ssa.Function, pos token.Pos) *ssa.Function {
+	for _, af := range f.AnonFuncs {
+		if af.Pos() == pos {
+			return af
+		}
+		if found := anonFuncAt(af, pos); found != nil {
+			return found
+		}
+	}
+	return nil
 }
 
 // findResourceFunc finds the resource func for both typed and untyped resources.
@@ -439,8 +833,12 @@ func followResourceFunc(prog *ssa.Program, pkg Package, f *types.Func) (*ssa.Fun
 	if l := len(fdecl.Body.List); l != 1 {
 		return nil, fmt.Errorf("expect resource function body to contain only one statement, got=%d", l)
 	}
-	// Here we can assume the only statement is a return statement, which has only one result, per the signature of sdk.ResourceFunc.
-	res := fdecl.Body.List[0].(*ast.ReturnStmt).Results[0]
+	// The only statement is expected to be a return statement, which has only one result, per the signature of sdk.ResourceFunc.
+	ret, ok := fdecl.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil, fmt.Errorf("unexpected statement of resource function %q, expect to be a return statement with one result", f.String())
+	}
+	res := ret.Results[0]
 	callexpr, ok := res.(*ast.CallExpr)
 	if !ok {
 		return nil, fmt.Errorf("unexpected return value of resource function %q, expect to be a call expression", f.String())
@@ -449,13 +847,19 @@ func followResourceFunc(prog *ssa.Program, pkg Package, f *types.Func) (*ssa.Fun
 	switch fun := callexpr.Fun.(type) {
 	case *ast.SelectorExpr:
 		// Method call
-		recv := pkg.pkg.TypesInfo.TypeOf(fun.X).(*types.Named)
+		recv, ok := pkg.pkg.TypesInfo.TypeOf(fun.X).(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("unexpected receiver type of the returned call expression from %s: %s", f.String(), pkg.pkg.TypesInfo.TypeOf(fun.X))
+		}
 		methodName := fun.Sel.Name
 		ssaFunc := prog.LookupMethod(recv, pkg.pkg.Types, methodName)
 		return findResourceFunc(prog, pkg, ssaFunc)
 	case *ast.Ident:
 		// Regular function call
-		fobj := pkg.pkg.TypesInfo.ObjectOf(fun).(*types.Func)
+		fobj, ok := pkg.pkg.TypesInfo.ObjectOf(fun).(*types.Func)
+		if !ok {
+			return nil, fmt.Errorf("unexpected returned call expression function object from %s: %s", f.String(), fun.Name)
+		}
 		return findResourceFunc(prog, pkg, typeutils.SSAFunction(pkg.ssa, fobj.Name()))
 	default:
 		return nil, fmt.Errorf("unexpected returned call expression function type from %s: %T", f.String(), fun)
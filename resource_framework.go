@@ -0,0 +1,329 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/magodo/aztfo/typeutils"
+	"golang.org/x/tools/go/ssa"
+)
+
+// frameworkResourceMethods are the entry methods of the resources implemented by the terraform-plugin-framework, keyed by
+// the resource kind. The first method is required, while the others are optional.
+var frameworkResourceMethods = map[ResourceKind][]string{
+	ResourceKindResource:   {"Read", "Create", "Update", "Delete", "ImportState", "ModifyPlan", "UpgradeState"},
+	ResourceKindDataSource: {"Read"},
+	ResourceKindEphemeral:  {"Open", "Renew", "Close"},
+	ResourceKindList:       {"List"},
+	ResourceKindAction:     {"Invoke"},
+}
+
+// findFrameworkResource finds the resources implemented by the terraform-plugin-framework, that are registered by the
+// service registration methods, i.e. FrameworkResources, FrameworkDataSources, EphemeralResources, ListResources and Actions.
+func findFrameworkResource(pkg Package, f *types.Func, kind ResourceKind, _ ResourceRules, diags *Diagnostics) (ResourceInfos, error) {
+	if f == nil {
+		return nil, nil
+	}
+	fdecl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg.pkg, f)
+	if err != nil {
+		return nil, fmt.Errorf("lookup function declaration from object of %q failed: %v", f.Id(), err)
+	}
+
+	// Mostly this function contains only a composite literal of the resource constructors, e.g.
+	//
+	//	return []func() ephemeral.EphemeralResource{
+	//		NewKeyVaultSecretEphemeralResource,
+	//		NewKeyVaultCertificateEphemeralResource,
+	//	}
+	//
+	// Or a composite literal of the resources wrapped by the provider's framework wrapper, e.g.
+	//
+	//	return []sdk.FrameworkWrappedResource{
+	//		ManagedRedisResource{},
+	//		NewStorageShareResource(),
+	//	}
+	var (
+		entries []frameworkResourceEntry
+		// found is set if the resources are found to be registered as expected, including none.
+		found bool
+	)
+	ast.Inspect(fdecl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ReturnStmt:
+			if len(n.Results) == 1 {
+				if ident, ok := n.Results[0].(*ast.Ident); ok && ident.Name == "nil" {
+					found = true
+				}
+			}
+			return true
+		case *ast.CompositeLit:
+			at, ok := n.Type.(*ast.ArrayType)
+			if !ok {
+				return true
+			}
+			found = true
+			_, isCtor := at.Elt.(*ast.FuncType)
+			for _, e := range n.Elts {
+				entry, ok := newFrameworkResourceEntry(pkg, e, isCtor)
+				if !ok {
+					if herr := diags.Handle(Diagnostic{Position: position(pkg.pkg.Fset, e.Pos()), Reason: fmt.Sprintf("unexpected %s constructor expression type: %T", kind, e)}); herr != nil {
+						err = multierror.Append(err, herr)
+						return false
+					}
+					continue
+				}
+				entries = append(entries, entry)
+			}
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if err := diags.Handle(Diagnostic{Position: position(pkg.pkg.Fset, fdecl.Pos()), Reason: fmt.Sprintf("no %s constructor is recognized in %s", kind, f.Name())}); err != nil {
+			return nil, err
+		}
+	}
+
+	infos := ResourceInfos{}
+	for _, entry := range entries {
+		rt := entry.typ
+		if entry.ctor != nil {
+			rt, err = frameworkResourceCtorType(pkg, entry.ctor, kind)
+		}
+		var (
+			rid   ResourceId
+			funcs ResourceFuncs
+		)
+		if err == nil {
+			rid, funcs, err = frameworkResourceFuncs(pkg, rt, kind)
+		}
+		if err != nil {
+			resource := entry.name
+			if rid.Name != "" {
+				resource = rid.Address()
+			}
+			if err := diags.Handle(Diagnostic{Resource: resource, Position: position(pkg.pkg.Fset, entry.pos), Reason: err.Error()}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		infos[rid] = funcs
+	}
+	return infos, nil
+}
+
+// frameworkResourceEntry is a framework resource registered by the service registration method, either by its
+// constructor, or by the resource itself (i.e. the wrapped resources).
+type frameworkResourceEntry struct {
+	// name is the name of the constructor or the resource type, used in the diagnostics.
+	name string
+	pos  token.Pos
+	// Exactly one of the ctor and typ is set.
+	ctor *types.Func
+	typ  types.Type
+}
+
+// newFrameworkResourceEntry parses an element of the composite literal in the registration method. If isCtor is true,
+// the element is expected to be a constructor (e.g. NewFooResource). Otherwise, it is expected to be the resource, either
+// constructed in place (e.g. FooResource{} or &FooResource{}) or returned by a constructor call (e.g. NewFooResource()).
+func newFrameworkResourceEntry(pkg Package, e ast.Expr, isCtor bool) (frameworkResourceEntry, bool) {
+	funcOf := func(e ast.Expr) *types.Func {
+		var ident *ast.Ident
+		switch e := e.(type) {
+		case *ast.Ident:
+			ident = e
+		case *ast.SelectorExpr:
+			ident = e.Sel
+		}
+		if ident == nil {
+			return nil
+		}
+		f, _ := pkg.pkg.TypesInfo.Uses[ident].(*types.Func)
+		return f
+	}
+
+	if isCtor {
+		if ctor := funcOf(e); ctor != nil {
+			return frameworkResourceEntry{name: ctor.Name(), pos: ctor.Pos(), ctor: ctor}, true
+		}
+		return frameworkResourceEntry{}, false
+	}
+	if call, ok := e.(*ast.CallExpr); ok {
+		if ctor := funcOf(call.Fun); ctor != nil {
+			return frameworkResourceEntry{name: ctor.Name(), pos: ctor.Pos(), ctor: ctor}, true
+		}
+		return frameworkResourceEntry{}, false
+	}
+	t := pkg.pkg.TypesInfo.TypeOf(e)
+	if t == nil || types.IsInterface(t) {
+		return frameworkResourceEntry{}, false
+	}
+	return frameworkResourceEntry{name: types.TypeString(t, types.RelativeTo(pkg.pkg.Types)), pos: e.Pos(), typ: t}, true
+}
+
+// frameworkResourceCtorType finds the type of the framework resource, given its constructor, e.g.
+//
+//	func NewKeyVaultSecretEphemeralResource() ephemeral.EphemeralResource {
+//		return &KeyVaultSecretEphemeralResource{}
+//	}
+func frameworkResourceCtorType(pkg Package, ctor *types.Func, kind ResourceKind) (types.Type, error) {
+	ctorDecl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg.pkg, ctor)
+	if err != nil {
+		return nil, fmt.Errorf("lookup function declaration from object of %q failed: %v", ctor.Id(), err)
+	}
+	var rt types.Type
+	ast.Inspect(ctorDecl.Body, func(n ast.Node) bool {
+		if rt != nil {
+			return false
+		}
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok {
+			return true
+		}
+		if len(ret.Results) == 1 {
+			rt = pkg.pkg.TypesInfo.TypeOf(ret.Results[0])
+		}
+		return false
+	})
+	if rt == nil {
+		return nil, fmt.Errorf("unexpected %s constructor %q, expect to return the %s", kind, ctor.Name(), kind)
+	}
+	return rt, nil
+}
+
+// frameworkResourceFuncs finds the resource id and the entry functions (see frameworkResourceMethods) of the framework
+// resource type. The methods of the wrapped resources have extra parameters (e.g. the decoded model and the client
+// metadata), but are named the same.
+func frameworkResourceFuncs(pkg Package, rt types.Type, kind ResourceKind) (ResourceId, ResourceFuncs, error) {
+	prog := pkg.ssa.Prog
+	mset := prog.MethodSets.MethodSet(rt)
+
+	name, err := frameworkResourceName(pkg, mset, rt)
+	if err != nil {
+		return ResourceId{}, ResourceFuncs{}, err
+	}
+	rid := ResourceId{Name: name, Kind: kind}
+
+	// Retrieve the methods, where only the first one is required.
+	funcs := ResourceFuncs{}
+	for i, methodName := range frameworkResourceMethods[kind] {
+		sel := mset.Lookup(pkg.pkg.Types, methodName)
+		if sel == nil {
+			if i == 0 {
+				return rid, ResourceFuncs{}, fmt.Errorf("method %s of %q not found", methodName, rt)
+			}
+			continue
+		}
+		f := prog.MethodValue(sel)
+		if f == nil {
+			return rid, ResourceFuncs{}, fmt.Errorf("failed to find the ssa function determined by %q", sel.String())
+		}
+		switch methodName {
+		case "Create":
+			funcs.C = f
+		case "Read":
+			funcs.R = f
+		case "Update":
+			funcs.U = f
+		case "Delete":
+			funcs.D = f
+		case "ImportState":
+			funcs.Import = []*ssa.Function{f}
+		case "ModifyPlan":
+			funcs.Plan = []*ssa.Function{f}
+		case "UpgradeState":
+			// The state upgraders are returned as function literals (or named functions), e.g.
+			//
+			//	return map[int64]resource.StateUpgrader{
+			//		0: {StateUpgrader: upgradeFooStateV0toV1},
+			//	}
+			decl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg.pkg, sel.Obj().(*types.Func))
+			if err != nil {
+				return rid, ResourceFuncs{}, fmt.Errorf("lookup function declaration from object of %q failed: %v", sel.Obj().Id(), err)
+			}
+			funcs.Migrate = referencedFuncs(pkg, f, decl.Body)
+		case "Open":
+			funcs.Open = f
+		case "Renew":
+			funcs.Renew = f
+		case "Close":
+			funcs.Close = f
+		case "List":
+			funcs.List = f
+		case "Invoke":
+			funcs.Invoke = f
+		}
+	}
+	return rid, funcs, nil
+}
+
+// frameworkResourceName finds the resource type of the framework resource. For the wrapped resources, it is from the
+// ResourceType method, e.g.
+//
+//	func (r ManagedRedisResource) ResourceType() string {
+//		return "azurerm_managed_redis"
+//	}
+//
+// Otherwise, it is from the Metadata method, e.g.
+//
+//	func (e *KeyVaultSecretEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+//		resp.TypeName = "azurerm_key_vault_secret"
+//	}
+func frameworkResourceName(pkg Package, mset *types.MethodSet, rt types.Type) (string, error) {
+	if sel := mset.Lookup(pkg.pkg.Types, "ResourceType"); sel != nil {
+		decl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg.pkg, sel.Obj().(*types.Func))
+		if err != nil {
+			return "", fmt.Errorf("lookup function declaration from object of %q failed: %v", sel.Obj().Id(), err)
+		}
+		var name string
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			ret, ok := n.(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				return true
+			}
+			if tv, ok := pkg.pkg.TypesInfo.Types[ret.Results[0]]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+				name = constant.StringVal(tv.Value)
+			}
+			return false
+		})
+		if name == "" {
+			return "", fmt.Errorf("unexpected ResourceType implementation of %q, expect to return a constant string", rt)
+		}
+		return name, nil
+	}
+
+	sel := mset.Lookup(pkg.pkg.Types, "Metadata")
+	if sel == nil {
+		return "", fmt.Errorf("neither method ResourceType nor Metadata of %q found", rt)
+	}
+	decl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg.pkg, sel.Obj().(*types.Func))
+	if err != nil {
+		return "", fmt.Errorf("lookup function declaration from object of %q failed: %v", sel.Obj().Id(), err)
+	}
+	var name string
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return true
+		}
+		lhs, ok := assign.Lhs[0].(*ast.SelectorExpr)
+		if !ok || lhs.Sel.Name != "TypeName" {
+			return true
+		}
+		if tv, ok := pkg.pkg.TypesInfo.Types[assign.Rhs[0]]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			name = constant.StringVal(tv.Value)
+		}
+		return false
+	})
+	if name == "" {
+		return "", fmt.Errorf("unexpected Metadata implementation of %q, expect to assign a constant string to the TypeName", rt)
+	}
+	return name, nil
+}
@@ -1,75 +1,292 @@
 package main
 
 import (
+	"encoding/json"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/ssa"
 )
 
 func TestFindResources(t *testing.T) {
 	t.Parallel()
-	pkgs, _, err := loadPackages("./internal/testmodule/resource/services/empty", nil, []string{"."})
+	pkgs, err := loadPackages("./internal/testmodule/resource/services/empty", []string{"."})
 	require.NoError(t, err)
 
-	infos, err := findResources(pkgs)
+	infos, err := findResources(pkgs, defaultConfig(t).Resource, nil)
 	require.NoError(t, err)
 
-	require.Equal(t, 8, len(infos))
+	require.Equal(t, 15, len(infos))
 
 	{
-		info := infos[ResourceId{Name: "untyped_datasource", IsDataSource: true}]
+		info := infos[ResourceId{Name: "untyped_datasource", Kind: ResourceKindDataSource}]
 		require.Nil(t, info.C)
 		require.Nil(t, info.U)
 		require.Nil(t, info.D)
 		require.Equal(t, "untypedDataSourceRead", info.R.Object().Name())
 	}
 	{
-		info := infos[ResourceId{Name: "untyped_resource", IsDataSource: false}]
+		info := infos[ResourceId{Name: "untyped_resource"}]
 		require.Equal(t, "untypedResourceCreate", info.C.Object().Name())
 		require.Equal(t, "untypedResourceRead", info.R.Object().Name())
 		require.Equal(t, "untypedResourceUpdate", info.U.Object().Name())
 		require.Equal(t, "untypedResourceDelete", info.D.Object().Name())
 	}
 	{
-		info := infos[ResourceId{Name: "untyped_resource2", IsDataSource: false}]
+		info := infos[ResourceId{Name: "untyped_resource2"}]
 		require.Equal(t, "untypedResourceCreate", info.C.Object().Name())
 		require.Equal(t, "untypedResourceRead", info.R.Object().Name())
 		require.Equal(t, "untypedResourceUpdate", info.U.Object().Name())
 		require.Equal(t, "untypedResourceDelete", info.D.Object().Name())
 	}
 	{
-		info := infos[ResourceId{Name: "untyped_resource_indirect", IsDataSource: false}]
+		info := infos[ResourceId{Name: "untyped_resource_indirect"}]
 		require.Equal(t, "untypedResourceIndirectCreate$1", info.C.Name())
 		require.Equal(t, "untypedResourceIndirectRead$1", info.R.Name())
 		require.Equal(t, "untypedResourceIndirectUpdate$1", info.U.Name())
 		require.Equal(t, "untypedResourceIndirectDelete$1", info.D.Name())
 	}
 	{
-		info := infos[ResourceId{Name: "typed_datasource", IsDataSource: true}]
+		info := infos[ResourceId{Name: "typed_datasource", Kind: ResourceKindDataSource}]
 		require.Nil(t, info.C)
 		require.Nil(t, info.U)
 		require.Nil(t, info.D)
 		require.Equal(t, "(TypedDataSource).Read$1", info.R.RelString(pkgs[0].pkg.Types))
 	}
 	{
-		info := infos[ResourceId{Name: "typed_resource", IsDataSource: false}]
+		info := infos[ResourceId{Name: "typed_resource"}]
 		require.Equal(t, "(TypedResource).Create$1", info.C.RelString(pkgs[0].pkg.Types))
 		require.Equal(t, "(TypedResource).Read$1", info.R.RelString(pkgs[0].pkg.Types))
 		require.Equal(t, "(TypedResource).Update$1", info.U.RelString(pkgs[0].pkg.Types))
 		require.Equal(t, "(TypedResource).Delete$1", info.D.RelString(pkgs[0].pkg.Types))
 	}
 	{
-		info := infos[ResourceId{Name: "typed_resource_indirect", IsDataSource: false}]
+		info := infos[ResourceId{Name: "typed_resource_indirect"}]
 		require.Equal(t, "(TypedResourceIndirect).buildResourceFunc$1", info.C.RelString(pkgs[0].pkg.Types))
 		require.Equal(t, "buildResourceFunc$1", info.R.RelString(pkgs[0].pkg.Types))
 		require.Equal(t, "(TypedResourceIndirect).buildResourceFunc$1", info.U.RelString(pkgs[0].pkg.Types))
 		require.Equal(t, "buildResourceFunc$1", info.D.RelString(pkgs[0].pkg.Types))
 	}
 	{
-		info := infos[ResourceId{Name: "typed_resource_gen", IsDataSource: false}]
+		info := infos[ResourceId{Name: "typed_resource_gen"}]
 		require.Equal(t, "(TypedResourceGen).Create$1", info.C.RelString(pkgs[0].pkg.Types))
 		require.Equal(t, "(TypedResourceGen).Read$1", info.R.RelString(pkgs[0].pkg.Types))
 		require.Equal(t, "(TypedResourceGen).Update$1", info.U.RelString(pkgs[0].pkg.Types))
 		require.Equal(t, "(TypedResourceGen).Delete$1", info.D.RelString(pkgs[0].pkg.Types))
 	}
+
+	{
+		info := infos[ResourceId{Name: "ephemeral_resource", Kind: ResourceKindEphemeral}]
+		require.Nil(t, info.C)
+		require.Nil(t, info.R)
+		require.Nil(t, info.U)
+		require.Nil(t, info.D)
+		require.Equal(t, "(*EphemeralResource).Open", info.Open.RelString(pkgs[0].pkg.Types))
+		require.Equal(t, "(*EphemeralResource).Renew", info.Renew.RelString(pkgs[0].pkg.Types))
+		require.Equal(t, "(*EphemeralResource).Close", info.Close.RelString(pkgs[0].pkg.Types))
+	}
+
+	{
+		// The list resource shares the name with the managed resource.
+		info := infos[ResourceId{Name: "typed_resource", Kind: ResourceKindList}]
+		require.Nil(t, info.R)
+		require.Equal(t, "(*ListResource).List", info.List.RelString(pkgs[0].pkg.Types))
+	}
+	{
+		info := infos[ResourceId{Name: "action", Kind: ResourceKindAction}]
+		require.Nil(t, info.R)
+		require.Equal(t, "(Action).Invoke", info.Invoke.RelString(pkgs[0].pkg.Types))
+	}
+
+	funcNames := func(funcs []*ssa.Function) []string {
+		var names []string
+		for _, f := range funcs {
+			names = append(names, f.RelString(pkgs[0].pkg.Types))
+		}
+		return names
+	}
+	{
+		info := infos[ResourceId{Name: "untyped_resource"}]
+		require.Empty(t, info.Import)
+		require.Empty(t, info.Plan)
+		require.Empty(t, info.Migrate)
+	}
+	{
+		info := infos[ResourceId{Name: "untyped_resource_extra"}]
+		require.Equal(t, "untypedResourceRead", info.R.Object().Name())
+		// The pluginsdk helpers are not the entries, while the functions passed to them are.
+		require.Equal(t, []string{"untypedResourceExtra$1"}, funcNames(info.Import))
+		require.Equal(t, []string{
+			"untypedResourceExtraCustomizeDiffAll",
+			"untypedResourceExtraCustomizeDiff",
+		}, funcNames(info.Plan))
+		require.Equal(t, []string{
+			"(github.com/magodo/aztfo/internal/testmodule/resource/services/empty/migration.ExtraV0ToV1).UpgradeFunc$1",
+		}, funcNames(info.Migrate))
+	}
+	{
+		info := infos[ResourceId{Name: "typed_resource_extra"}]
+		require.Equal(t, "(TypedResourceExtra).Read$1", info.R.RelString(pkgs[0].pkg.Types))
+		require.Equal(t, []string{"(TypedResourceExtra).CustomImporter$1"}, funcNames(info.Import))
+		require.Equal(t, []string{"(TypedResourceExtra).CustomizeDiff$1"}, funcNames(info.Plan))
+		require.Equal(t, []string{
+			"(github.com/magodo/aztfo/internal/testmodule/resource/services/empty/migration.ExtraV0ToV1).UpgradeFunc$1",
+		}, funcNames(info.Migrate))
+	}
+	{
+		info := infos[ResourceId{Name: "framework_datasource", Kind: ResourceKindDataSource}]
+		require.Nil(t, info.C)
+		require.Nil(t, info.U)
+		require.Nil(t, info.D)
+		require.Equal(t, "(*FrameworkDataSource).Read", info.R.RelString(pkgs[0].pkg.Types))
+	}
+	{
+		info := infos[ResourceId{Name: "framework_resource"}]
+		require.Equal(t, "(*FrameworkResource).Create", info.C.RelString(pkgs[0].pkg.Types))
+		require.Equal(t, "(*FrameworkResource).Read", info.R.RelString(pkgs[0].pkg.Types))
+		require.Equal(t, "(*FrameworkResource).Update", info.U.RelString(pkgs[0].pkg.Types))
+		require.Equal(t, "(*FrameworkResource).Delete", info.D.RelString(pkgs[0].pkg.Types))
+		require.Equal(t, []string{"(*FrameworkResource).ImportState"}, funcNames(info.Import))
+		require.Equal(t, []string{"(*FrameworkResource).ModifyPlan"}, funcNames(info.Plan))
+		require.Equal(t, []string{
+			"frameworkResourceUpgradeV0",
+			"(*FrameworkResource).UpgradeState$1",
+		}, funcNames(info.Migrate))
+	}
+}
+
+func TestFindResourcesKeepGoing(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/resource/services/broken", []string{"."})
+	require.NoError(t, err)
+
+	_, err = findResources(pkgs, defaultConfig(t).Resource, nil)
+	require.Error(t, err)
+
+	diags := &Diagnostics{}
+	infos, err := findResources(pkgs, defaultConfig(t).Resource, diags)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(infos))
+	require.Equal(t, "untypedResourceRead", infos[ResourceId{Name: "untyped_resource"}].R.Object().Name())
+
+	items := diags.Items()
+	require.Equal(t, 1, len(items))
+	require.Equal(t, "untyped_resource_broken", items[0].Resource)
+	require.Contains(t, items[0].Position, "untyped_resource.go")
+}
+
+func TestFindResourcesFrameworkWrapper(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/resource/services/frameworkwrapper", []string{"."})
+	require.NoError(t, err)
+
+	// The EphemeralResources has no recognized constructor.
+	_, err = findResources(pkgs, defaultConfig(t).Resource, nil)
+	require.ErrorContains(t, err, "no ephemeral constructor is recognized in EphemeralResources")
+
+	diags := &Diagnostics{}
+	infos, err := findResources(pkgs, defaultConfig(t).Resource, diags)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(infos))
+	require.Len(t, diags.Items(), 1)
+	require.Contains(t, diags.Items()[0].Position, "registration.go")
+
+	rel := pkgs[0].pkg.Types
+	{
+		info := infos[ResourceId{Name: "wrapped_resource"}]
+		require.Equal(t, "(*WrappedResource).Create", info.C.RelString(rel))
+		require.Equal(t, "(*WrappedResource).Read", info.R.RelString(rel))
+		require.Equal(t, "(*WrappedResource).Update", info.U.RelString(rel))
+		require.Equal(t, "(*WrappedResource).Delete", info.D.RelString(rel))
+		require.Len(t, info.Import, 1)
+		require.Equal(t, "(*WrappedResource).ImportState", info.Import[0].RelString(rel))
+	}
+	{
+		// The CRUD methods are promoted from the embedded resource.
+		info := infos[ResourceId{Name: "wrapped_resource_ctor"}]
+		require.NotNil(t, info.C)
+		require.NotNil(t, info.R)
+	}
+	{
+		info := infos[ResourceId{Name: "wrapped_datasource", Kind: ResourceKindDataSource}]
+		require.Nil(t, info.C)
+		require.Equal(t, "(WrappedDataSource).Read", info.R.RelString(rel))
+	}
+
+	// The SDK methods called by the wrapped resource methods are reached.
+	a := NewSDKAnalyzerHashicorp(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/hashicorpsdk`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+	graph, err := buildCallGraph(pkgs, CallGraphAlgorithmStatic, []string{"github.com/magodo/aztfo/internal/testmodule"})
+	require.NoError(t, err)
+
+	const path = "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"
+	armPath := ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"}
+	opGet := APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: path, ARMPath: armPath, ContentType: "application/json; charset=utf-8", ExpectedStatusCodes: "200"}
+	opPut := APIOperation{Kind: OperationKindPut, Version: "2025-04-01", Path: path, ARMPath: armPath, IsLRO: true, ContentType: "application/json; charset=utf-8", ExpectedStatusCodes: "200,201,202"}
+
+	info := infos[ResourceId{Name: "wrapped_resource"}]
+	ops, _ := resReachSDK(graph, info.C, funcs, false)
+	require.Equal(t, APIOperations{opPut}, ops)
+	ops, _ = resReachSDK(graph, info.R, funcs, false)
+	require.Equal(t, APIOperations{opGet}, ops)
+	ops, _ = resReachSDK(graph, infos[ResourceId{Name: "wrapped_datasource", Kind: ResourceKindDataSource}].R, funcs, false)
+	require.Equal(t, APIOperations{opGet}, ops)
+}
+
+func TestResourceId(t *testing.T) {
+	t.Parallel()
+	ids := []ResourceId{
+		{Name: "azurerm_resource_group"},
+		{Name: "azurerm_resource_group", Kind: ResourceKindDataSource},
+		{Name: "azurerm_key_vault_secret", Kind: ResourceKindEphemeral},
+		{Name: "azurerm_resource_group", Kind: ResourceKindList},
+		{Name: "azurerm_virtual_machine_power", Kind: ResourceKindAction},
+	}
+	require.Equal(t, ids, parseResourceIds("azurerm_resource_group,data.azurerm_resource_group,ephemeral.azurerm_key_vault_secret,list.azurerm_resource_group,action.azurerm_virtual_machine_power"))
+
+	for _, id := range ids {
+		b, err := json.Marshal(id)
+		require.NoError(t, err)
+		var got ResourceId
+		require.NoError(t, json.Unmarshal(b, &got))
+		require.Equal(t, id, got)
+	}
+
+	b, err := json.Marshal(ids[1])
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name": "azurerm_resource_group", "kind": "data_source", "is_data_source": true}`, string(b))
+
+	// The reports without the kind
+	var got ResourceId
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "azurerm_resource_group", "is_data_source": true}`), &got))
+	require.Equal(t, ids[1], got)
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "azurerm_resource_group", "is_data_source": false}`), &got))
+	require.Equal(t, ids[0], got)
+}
+
+func TestFindResourcesRules(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/resource/services/empty", []string{"."})
+	require.NoError(t, err)
+
+	// The untyped and typed resources are not found, as they are defined with other SDK package names.
+	rules := ResourceRules{
+		Registrations:     []string{"Registration"},
+		UntypedSDKPackage: "schema",
+		TypedSDKPackage:   "typed",
+	}
+	infos, err := findResources(pkgs, rules, &Diagnostics{})
+	require.NoError(t, err)
+	require.Contains(t, infos, ResourceId{Name: "framework_resource"})
+	require.Contains(t, infos, ResourceId{Name: "framework_datasource", Kind: ResourceKindDataSource})
+	require.NotContains(t, infos, ResourceId{Name: "untyped_resource"})
+	require.NotContains(t, infos, ResourceId{Name: "untyped_datasource", Kind: ResourceKindDataSource})
+	require.NotContains(t, infos, ResourceId{Name: "typed_resource"})
+	require.NotContains(t, infos, ResourceId{Name: "typed_datasource", Kind: ResourceKindDataSource})
+
+	rules.Registrations = []string{"FooRegistration"}
+	_, err = findResources(pkgs, rules, nil)
+	require.ErrorContains(t, err, "registration (FooRegistration) not found")
 }
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// PrimaryResourceType is the ARM resource type that a Terraform resource manages.
+type PrimaryResourceType struct {
+	// ResourceType is the ARM resource type, e.g. "Microsoft.Foo/foos". It is empty if it can't be decided among the
+	// Candidates.
+	ResourceType string `json:"resource_type,omitempty"`
+	// APIVersions are the API versions of the operations on the ResourceType.
+	APIVersions []string `json:"api_versions,omitempty"`
+	// Candidates are the ARM resource types that the primary one can't be decided among.
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+// primaryResourceType infers the primary ARM resource type of the Terraform resource, from the ARM resource types of its
+// resource ID parsers (idTypes, see idParserResourceTypes), and of the PUT operations of the create and the DELETE
+// operations of the delete:
+//
+//   - If there is a single ID parser resource type, it is the primary one.
+//   - If there are multiple ID parser resource types, the one that is also operated by the PUT/DELETE operations is the primary one.
+//   - If there is no ID parser resource type, the one that is operated by the PUT/DELETE operations is the primary one.
+//
+// Otherwise, the candidates are listed instead. It returns nil if there is no candidate.
+func primaryResourceType(result Result, idTypes []string) *PrimaryResourceType {
+	opTypes := operationResourceTypes(result)
+
+	var candidates []string
+	switch len(idTypes) {
+	case 0:
+		candidates = opTypes
+	case 1:
+		candidates = idTypes
+	default:
+		candidates = idTypes
+		var matched []string
+		for _, t := range idTypes {
+			if containsResourceType(opTypes, t) {
+				matched = append(matched, t)
+			}
+		}
+		if len(matched) != 0 {
+			candidates = matched
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil
+	case 1:
+		return &PrimaryResourceType{
+			ResourceType: candidates[0],
+			APIVersions:  resourceTypeAPIVersions(result, candidates[0]),
+		}
+	default:
+		candidates = slices.Clone(candidates)
+		slices.Sort(candidates)
+		return &PrimaryResourceType{Candidates: candidates}
+	}
+}
+
+// operationResourceTypes returns the ARM resource types of both the PUT operations of the create, and the DELETE operations
+// of the delete. If there is none, it returns the ones of either of them.
+func operationResourceTypes(result Result) []string {
+	putTypes := resourceTypesOf(result.Create, OperationKindPut)
+	deleteTypes := resourceTypesOf(result.Delete, OperationKindDelete)
+	var out []string
+	for _, t := range putTypes {
+		if containsResourceType(deleteTypes, t) {
+			out = append(out, t)
+		}
+	}
+	if len(out) != 0 {
+		return out
+	}
+	out = putTypes
+	for _, t := range deleteTypes {
+		if !containsResourceType(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// resourceTypesOf returns the (case insensitively) deduplicated ARM resource types of the operations of the kind.
+func resourceTypesOf(ops APIOperations, kind OperationKind) []string {
+	var out []string
+	for _, op := range ops {
+		if op.Kind != kind || op.ARMPath.Action != "" {
+			continue
+		}
+		if t := op.ARMPath.ResourceType(); t != "" && !containsResourceType(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// resourceTypeAPIVersions returns the sorted API versions of the PUT operations of the create and the DELETE operations
+// of the delete, on the ARM resource type. If there is none, it returns the ones of the GET operations of the read.
+func resourceTypeAPIVersions(result Result, resourceType string) []string {
+	var versions []string
+	collect := func(ops APIOperations, kind OperationKind) {
+		for _, op := range ops {
+			if op.Kind == kind && op.ARMPath.Action == "" && strings.EqualFold(op.ARMPath.ResourceType(), resourceType) {
+				versions = append(versions, op.Version)
+			}
+		}
+	}
+	collect(result.Create, OperationKindPut)
+	collect(result.Delete, OperationKindDelete)
+	if len(versions) == 0 {
+		collect(result.Read, OperationKindGet)
+	}
+	slices.Sort(versions)
+	return slices.Compact(versions)
+}
+
+func containsResourceType(l []string, t string) bool {
+	return slices.ContainsFunc(l, func(e string) bool { return strings.EqualFold(e, t) })
+}
+
+// idParserResourceTypes returns the ARM resource types of the resource IDs that are parsed from the Terraform resource ID
+// in the functions (including their anonymous functions), e.g.
+//
+//	id, err := parse.FooID(d.Id())
+//	id, err := foos.ParseFooID(metadata.ResourceData.Id())
+//
+// The ID parser is a function that accepts a string, and returns a resource ID (or its pointer) and an error. The resource
+// ID type has the "ID() string" method, whose returned API path tells the ARM resource type. Only the string returned by
+// an "Id()" method (e.g. of the pluginsdk.ResourceData), or the string parameter of the function (e.g. the ID validation
+// function of the importer) is regarded as the Terraform resource ID.
+func idParserResourceTypes(fs []*ssa.Function) []string {
+	var out []string
+	for _, f := range fs {
+		walkInstrs(f, func(instr ssa.Instruction) bool {
+			call, ok := instr.(*ssa.Call)
+			if !ok || len(call.Call.Args) != 1 || !isTerraformResourceId(call.Call.Args[0]) {
+				return true
+			}
+			callee := call.Call.StaticCallee()
+			if callee == nil {
+				return true
+			}
+			idType := idParserType(callee.Signature)
+			if idType == nil {
+				return true
+			}
+			idFunc := resourceIdFunc(f.Prog, idType)
+			if idFunc == nil {
+				return true
+			}
+			if t := parseARMPath(apiPathFromFunc(idFunc), OperationKindGet).ResourceType(); t != "" && !containsResourceType(out, t) {
+				out = append(out, t)
+			}
+			return true
+		})
+	}
+	return out
+}
+
+// isTerraformResourceId tells whether the string value is the Terraform resource ID, i.e. returned by an "Id()" method, or
+// is a parameter.
+func isTerraformResourceId(v ssa.Value) bool {
+	if !isStringType(v.Type()) {
+		return false
+	}
+	switch v := v.(type) {
+	case *ssa.Parameter:
+		return true
+	case *ssa.Call:
+		if v.Call.IsInvoke() {
+			return v.Call.Method.Name() == "Id"
+		}
+		callee := v.Call.StaticCallee()
+		return callee != nil && callee.Signature.Recv() != nil && callee.Name() == "Id"
+	}
+	return false
+}
+
+// idParserType returns the resource ID type of the ID parser signature, i.e. "func(string) (T, error)" or
+// "func(string) (*T, error)". It returns nil if the signature is not an ID parser.
+func idParserType(sig *types.Signature) *types.Named {
+	if sig.Recv() != nil || sig.Params().Len() != 1 || !isStringType(sig.Params().At(0).Type()) {
+		return nil
+	}
+	if sig.Results().Len() != 2 || !types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type()) {
+		return nil
+	}
+	t := sig.Results().At(0).Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+	return named
+}
+
+// resourceIdFunc returns the "ID() string" method of the resource ID type. It returns nil if not found.
+func resourceIdFunc(prog *ssa.Program, idType *types.Named) *ssa.Function {
+	for _, t := range []types.Type{idType, types.NewPointer(idType)} {
+		sel := prog.MethodSets.MethodSet(t).Lookup(idType.Obj().Pkg(), "ID")
+		if sel == nil {
+			continue
+		}
+		sig := sel.Type().(*types.Signature)
+		if sig.Params().Len() != 0 || sig.Results().Len() != 1 || !isStringType(sig.Results().At(0).Type()) {
+			return nil
+		}
+		return prog.MethodValue(sel)
+	}
+	return nil
+}
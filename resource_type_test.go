@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/ssa"
+)
+
+func TestIDParserResourceTypes(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/resourcetypeuser", []string{"."})
+	require.NoError(t, err)
+
+	fn := func(name string) *ssa.Function {
+		f := pkgs[0].ssa.Func(name)
+		require.NotNil(t, f)
+		return f
+	}
+
+	// The resource group id is not parsed from the Terraform resource ID.
+	require.Equal(t, []string{"Microsoft.Foo/foos"}, idParserResourceTypes([]*ssa.Function{fn("read")}))
+	require.Equal(t, []string{"Microsoft.Foo/foos", "Microsoft.Foo/foos/bars"}, idParserResourceTypes([]*ssa.Function{fn("read"), fn("importer")}))
+	require.Equal(t, []string{"Microsoft.Resources/subscriptions/resourceGroups"}, idParserResourceTypes([]*ssa.Function{fn("readResourceGroup")}))
+	require.Empty(t, idParserResourceTypes([]*ssa.Function{fn("main"), nil}))
+}
+
+func TestPrimaryResourceType(t *testing.T) {
+	t.Parallel()
+	var (
+		foos = ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"}
+		bars = ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos/bars"}
+		bazs = ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Baz", ResourceTypes: "bazs"}
+
+		getFoo    = APIOperation{Kind: OperationKindGet, Version: "2025-01-01", ARMPath: foos}
+		putFoo    = APIOperation{Kind: OperationKindPut, Version: "2025-04-01", ARMPath: foos}
+		deleteFoo = APIOperation{Kind: OperationKindDelete, Version: "2025-04-01", ARMPath: foos}
+		putBar    = APIOperation{Kind: OperationKindPut, Version: "2025-04-01", ARMPath: bars}
+		deleteBar = APIOperation{Kind: OperationKindDelete, Version: "2025-04-01", ARMPath: bars}
+		putBaz    = APIOperation{Kind: OperationKindPut, Version: "2024-01-01", ARMPath: bazs}
+		deleteBaz = APIOperation{Kind: OperationKindDelete, Version: "2024-01-01", ARMPath: bazs}
+		// The casing of the SDK differs from the one of the ID parser.
+		putFooV2 = APIOperation{Kind: OperationKindPut, Version: "2025-06-01", ARMPath: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "Foos"}}
+		listKeys = APIOperation{Kind: OperationKindPost, Version: "2025-04-01", ARMPath: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos", Action: "listKeys"}}
+	)
+
+	cases := []struct {
+		name    string
+		result  Result
+		idTypes []string
+		expect  *PrimaryResourceType
+	}{
+		{
+			name:    "single ID parser",
+			result:  Result{Create: APIOperations{putFoo, putFooV2, putBaz, listKeys}, Delete: APIOperations{deleteFoo}},
+			idTypes: []string{"Microsoft.Foo/foos"},
+			expect:  &PrimaryResourceType{ResourceType: "Microsoft.Foo/foos", APIVersions: []string{"2025-04-01", "2025-06-01"}},
+		},
+		{
+			name:    "multiple ID parsers",
+			result:  Result{Create: APIOperations{putBar}, Delete: APIOperations{deleteBar}},
+			idTypes: []string{"Microsoft.Foo/foos", "Microsoft.Foo/foos/bars"},
+			expect:  &PrimaryResourceType{ResourceType: "Microsoft.Foo/foos/bars", APIVersions: []string{"2025-04-01"}},
+		},
+		{
+			name:    "multiple ID parsers without operations",
+			result:  Result{Read: APIOperations{getFoo}},
+			idTypes: []string{"Microsoft.Foo/foos/bars", "Microsoft.Foo/foos"},
+			expect:  &PrimaryResourceType{Candidates: []string{"Microsoft.Foo/foos", "Microsoft.Foo/foos/bars"}},
+		},
+		{
+			name:    "API versions of the read",
+			result:  Result{Read: APIOperations{getFoo}},
+			idTypes: []string{"Microsoft.Foo/foos"},
+			expect:  &PrimaryResourceType{ResourceType: "Microsoft.Foo/foos", APIVersions: []string{"2025-01-01"}},
+		},
+		{
+			name:   "both PUT and DELETE",
+			result: Result{Create: APIOperations{putFoo, putBaz}, Delete: APIOperations{deleteFoo}},
+			expect: &PrimaryResourceType{ResourceType: "Microsoft.Foo/foos", APIVersions: []string{"2025-04-01"}},
+		},
+		{
+			name:   "ambiguous PUT and DELETE",
+			result: Result{Create: APIOperations{putFoo, putBaz}, Delete: APIOperations{deleteFoo, deleteBaz}},
+			expect: &PrimaryResourceType{Candidates: []string{"Microsoft.Baz/bazs", "Microsoft.Foo/foos"}},
+		},
+		{
+			name:   "either PUT or DELETE",
+			result: Result{Create: APIOperations{putFoo}, Delete: APIOperations{deleteBar}},
+			expect: &PrimaryResourceType{Candidates: []string{"Microsoft.Foo/foos", "Microsoft.Foo/foos/bars"}},
+		},
+		{
+			name:   "no clue",
+			result: Result{Create: APIOperations{listKeys}, Read: APIOperations{getFoo}},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, primaryResourceType(tt.result, tt.idTypes))
+		})
+	}
+}
@@ -1,5 +1,12 @@
 package main
 
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
 type Results []Result
 
 func (r Results) Len() int {
@@ -20,4 +27,82 @@ type Result struct {
 	Read   APIOperations `json:"read,omitempty"`
 	Update APIOperations `json:"update,omitempty"`
 	Delete APIOperations `json:"delete,omitempty"`
+	// Import, Plan and Migrate are the API operations invoked by the importer, the customize diff and the state upgraders,
+	// during "terraform import", "terraform plan" and the state migration respectively.
+	Import  APIOperations `json:"import,omitempty"`
+	Plan    APIOperations `json:"plan,omitempty"`
+	Migrate APIOperations `json:"migrate,omitempty"`
+	// Open, Renew and Close are the API operations of the ephemeral resources, in place of the CRUD.
+	Open  APIOperations `json:"open,omitempty"`
+	Renew APIOperations `json:"renew,omitempty"`
+	Close APIOperations `json:"close,omitempty"`
+	// List is the API operations of the list resources, and Invoke is the API operations of the actions.
+	List   APIOperations `json:"list,omitempty"`
+	Invoke APIOperations `json:"invoke,omitempty"`
+
+	// PrimaryResourceType is the ARM resource type that the resource manages, inferred from its resource ID parsers and
+	// the PUT/DELETE operations. It is only recorded for the resources.
+	PrimaryResourceType *PrimaryResourceType `json:"primary_resource_type,omitempty"`
+
+	// Evidence records the call path of each API operation, keyed by the verb.
+	// It is only recorded when the "-evidence" option is specified.
+	Evidence map[string]OperationEvidences `json:"evidence,omitempty"`
+
+	// Polling records the polling operations of the long running operations and custom pollers, keyed by the verb.
+	// It is only recorded when the "-polling" option is specified.
+	Polling map[string]PollingOperations `json:"polling,omitempty"`
+
+	// Permissions records the permissions required by the API operations, keyed by the verb.
+	// It is only recorded when the config has the permission rules.
+	Permissions map[string][]string `json:"permissions,omitempty"`
+
+	// Sensitive records the sensitive API operations that expose the credentials (e.g. the access keys, secrets and SAS
+	// tokens), with the reasons, keyed by the verb.
+	Sensitive map[string]SensitiveOperations `json:"sensitive,omitempty"`
+}
+
+// readResults reads the Results from an aztfo output file. A path of "-" reads from the stdin.
+func readResults(path string) (Results, error) {
+	var (
+		b   []byte
+		err error
+	)
+	if path == "-" {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %v", path, err)
+	}
+	var results Results
+	if err := json.Unmarshal(b, &results); err != nil {
+		return nil, fmt.Errorf("unmarshal %q: %v", path, err)
+	}
+	return results, nil
+}
+
+// VerbOperations is the API operations of a Terraform resource verb (e.g. "create").
+type VerbOperations struct {
+	Verb       string
+	Operations APIOperations
+}
+
+// VerbOperations returns the API operations of each verb of the result, in a fixed order.
+// The verb names are the same as the JSON field names.
+func (r Result) VerbOperations() []VerbOperations {
+	return []VerbOperations{
+		{Verb: "create", Operations: r.Create},
+		{Verb: "read", Operations: r.Read},
+		{Verb: "update", Operations: r.Update},
+		{Verb: "delete", Operations: r.Delete},
+		{Verb: "import", Operations: r.Import},
+		{Verb: "plan", Operations: r.Plan},
+		{Verb: "migrate", Operations: r.Migrate},
+		{Verb: "open", Operations: r.Open},
+		{Verb: "renew", Operations: r.Renew},
+		{Verb: "close", Operations: r.Close},
+		{Verb: "list", Operations: r.List},
+		{Verb: "invoke", Operations: r.Invoke},
+	}
 }
@@ -1,13 +1,16 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/types"
 	"log"
 	"maps"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/magodo/aztfo/typeutils"
@@ -27,6 +30,9 @@ const (
 	OperationKindPatch                 = "PATCH"
 )
 
+// PlaneData is the Plane of the data-plane API operations.
+const PlaneData = "data"
+
 type APIOperations []APIOperation
 
 func (a APIOperations) Len() int {
@@ -44,15 +50,38 @@ func (a APIOperations) Less(i int, j int) bool {
 	if x.Kind != y.Kind {
 		return x.Kind < y.Kind
 	}
+	if x.Plane != y.Plane {
+		return x.Plane < y.Plane
+	}
+	if x.Endpoint != y.Endpoint {
+		return x.Endpoint < y.Endpoint
+	}
+	if x.ContentType != y.ContentType {
+		return x.ContentType < y.ContentType
+	}
+	if x.QueryParameters != y.QueryParameters {
+		return x.QueryParameters < y.QueryParameters
+	}
+	if x.Headers != y.Headers {
+		return x.Headers < y.Headers
+	}
+	if x.ExpectedStatusCodes != y.ExpectedStatusCodes {
+		return x.ExpectedStatusCodes < y.ExpectedStatusCodes
+	}
+	if x.ARMPath != y.ARMPath {
+		return x.ARMPath.less(y.ARMPath)
+	}
 	if x.IsLRO != y.IsLRO {
 		return x.IsLRO
 	}
-	return true
+	return false
 }
 
+// Union adds the API operations that are not yet in the list, where the ones only differ in the ARMPath casing are regarded
+// as the same (see APIOperation.key).
 func (a *APIOperations) Union(b APIOperations) {
 	for _, op := range b {
-		if !slices.Contains(*a, op) {
+		if !slices.ContainsFunc(*a, func(x APIOperation) bool { return x.key() == op.key() }) {
 			*a = append(*a, op)
 		}
 	}
@@ -67,6 +96,113 @@ type APIOperation struct {
 	Version string        `json:"version"`
 	Path    string        `json:"path"`
 	IsLRO   bool          `json:"is_lro"`
+	// ARMPath is the structured form of the Path in the original casing, which is zero for the non-ARM operations (e.g.
+	// the data-plane and Microsoft Graph operations).
+	ARMPath ARMPath `json:"arm_path,omitzero"`
+	// Plane is "data" for the data-plane operations (e.g. the Key Vault secrets and the storage blobs), and is empty for the
+	// management plane (i.e. ARM) operations.
+	Plane string `json:"plane,omitempty"`
+	// Endpoint is the endpoint of the data-plane operation, e.g. "https://{}.vault.azure.net".
+	Endpoint string `json:"endpoint,omitempty"`
+	// ContentType is the content type of the request body, e.g. "application/json; charset=utf-8".
+	ContentType string `json:"content_type,omitempty"`
+	// QueryParameters are the query parameter keys that the SDK can set, e.g. "$expand", excluding the "api-version".
+	QueryParameters StringList `json:"query_parameters,omitempty"`
+	// Headers are the request header names that the SDK can set, e.g. "If-Match".
+	Headers StringList `json:"headers,omitempty"`
+	// ExpectedStatusCodes are the response status codes that the SDK regards as success.
+	ExpectedStatusCodes IntList `json:"expected_status_codes,omitempty"`
+}
+
+// key returns the API operation without the ARMPath, which identifies the API operation. The ARMPath is in the original
+// casing of the SDK, which can differ between the SDK functions of the same API operation.
+func (op APIOperation) key() APIOperation {
+	op.ARMPath = ARMPath{}
+	return op
+}
+
+// SDKFunction is the API operation that a SDK function corresponds to, together with the information of the SDK function
+// that is only used during the analysis. The latter is kept out of the APIOperation, as it doesn't identify the API operation.
+type SDKFunction struct {
+	Operation APIOperation
+
+	// versionParam is the 1-based index of the parameter of the SDK function that specifies the API version, in which case
+	// the Operation.Version is "unknown". It is resolved to the actual API versions at each call site (see resReachSDK).
+	versionParam int
+
+	// finalStateVia is where the poller of the LRO fetches the final state from (e.g. "original-uri"), if it is pinned by
+	// the SDK. It is not the response header that the poller follows, which is decided in runtime.
+	finalStateVia string
+}
+
+// SDKFunctions are the SDK functions that correspond to the API operations.
+type SDKFunctions map[*ssa.Function]SDKFunction
+
+// StringList is a sorted and deduplicated list of strings. It is stored as a comma separated string, to keep the
+// APIOperation comparable (e.g. as a map key), and is encoded as a JSON array.
+type StringList string
+
+func newStringList(l []string) StringList {
+	l = slices.Clone(l)
+	slices.Sort(l)
+	return StringList(strings.Join(slices.Compact(l), ","))
+}
+
+// Values returns the strings of the list.
+func (l StringList) Values() []string {
+	if l == "" {
+		return nil
+	}
+	return strings.Split(string(l), ",")
+}
+
+func (l StringList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Values())
+}
+
+func (l *StringList) UnmarshalJSON(b []byte) error {
+	var v []string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*l = newStringList(v)
+	return nil
+}
+
+// IntList is a sorted and deduplicated list of integers, which is the same as StringList except the element type.
+type IntList string
+
+func newIntList(l []int) IntList {
+	l = slices.Clone(l)
+	slices.Sort(l)
+	var ss []string
+	for _, i := range slices.Compact(l) {
+		ss = append(ss, strconv.Itoa(i))
+	}
+	return IntList(strings.Join(ss, ","))
+}
+
+// Values returns the integers of the list.
+func (l IntList) Values() []int {
+	var out []int
+	for _, s := range StringList(l).Values() {
+		i, _ := strconv.Atoi(s)
+		out = append(out, i)
+	}
+	return out
+}
+
+func (l IntList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Values())
+}
+
+func (l *IntList) UnmarshalJSON(b []byte) error {
+	var v []int
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*l = newIntList(v)
+	return nil
 }
 
 type SDKMethod struct {
@@ -87,31 +223,18 @@ type SDKAnalyzer interface {
 	PackagePattern() *regexp.Regexp
 
 	// FindSDKAPIFuncs looks into the "pkgs" to find all the used Go SDK functions/methods that corresponds to an API operation.
-	FindSDKAPIFuncs(pkgs Packages) (map[*ssa.Function]APIOperation, error)
+	// In the keep-going mode (i.e. diags is not nil), the SDK methods that failed to be analyzed are recorded as diagnostics and skipped.
+	FindSDKAPIFuncs(pkgs Packages, diags *Diagnostics) (SDKFunctions, error)
 }
 
-// findSDKAPIFuncs finds the SDK API related functions defiend by the imported SDK packages from pkgs.
-// The SDK can be either the Azure Track1 SDK or Hashicorp SDK.
-func findSDKAPIFuncs(pkgs Packages) (map[*ssa.Function]APIOperation, error) {
+// findSDKAPIFuncs finds the SDK API related functions defiend by the imported SDK packages from pkgs, using the SDK analyzers.
+func findSDKAPIFuncs(pkgs Packages, sdkAnalyzers []SDKAnalyzer, diags *Diagnostics) (SDKFunctions, error) {
 	log.Println("Find SDK API functions: begin")
 	defer log.Println("Find SDK API functions: end")
 
-	sdkAnalyzers := []SDKAnalyzer{
-		NewSDKAnalyzerAzure(
-			regexp.MustCompile(
-				`github.com/Azure/azure-sdk-for-go/services/(preview/)?[\w-]+/mgmt|` +
-					`github.com/jackofallops/kermit/sdk/[\w-]+`,
-			),
-		),
-		NewSDKAnalyzerHashicorp(
-			regexp.MustCompile(`github.com/hashicorp/go-azure-sdk/resource-manager`),
-			pkgs.Pkgs(),
-		),
-	}
-
-	res := map[*ssa.Function]APIOperation{}
+	res := SDKFunctions{}
 	for _, sdkanalyzer := range sdkAnalyzers {
-		funcs, err := sdkanalyzer.FindSDKAPIFuncs(pkgs)
+		funcs, err := sdkanalyzer.FindSDKAPIFuncs(pkgs, diags)
 		if err != nil {
 			return nil, err
 		}
@@ -121,76 +244,90 @@ func findSDKAPIFuncs(pkgs Packages) (map[*ssa.Function]APIOperation, error) {
 }
 
 // usedSDKMethods gathers all the SDK methods that the "pkgs" used.
-// It basically finds all "SDK" packages imported by the "pkgs", iterate each of them, looking for
-// method calls whose receiver is defined in "SDK" packages.
-func usedSDKMethods(a SDKAnalyzer, pkgs []*packages.Package) map[SDKMethod]struct{} {
-	sdkPkgMap := map[*packages.Package]struct{}{}
-
+// It basically finds all "SDK" packages (transitively) imported by the "pkgs", then iterates the SSA functions of the "pkgs",
+// looking for the functions referenced by the instructions (e.g. the callee of a static call, or a method value that is
+// passed as a callback), which are methods whose receiver is defined in the "SDK" packages. This makes it regardless of how
+// the receiver is expressed, e.g. "meta.(*clients.Client).Compute.VMClient.Get(...)".
+func usedSDKMethods(a SDKAnalyzer, pkgs Packages, diags *Diagnostics) (map[SDKMethod]struct{}, error) {
 	// Filter the imported packages to only keep the SDK packages.
-	for _, pkg := range pkgs {
-		for _, epkg := range pkg.Imports {
-			if !a.PackagePattern().MatchString(epkg.PkgPath) {
-				continue
-			}
-			sdkPkgMap[epkg] = struct{}{}
-		}
-	}
 	var sdkPkgs []*packages.Package
-	for epkg := range sdkPkgMap {
-		sdkPkgs = append(sdkPkgs, epkg)
-	}
+	packages.Visit(pkgs.Pkgs(), nil, func(epkg *packages.Package) {
+		if a.PackagePattern().MatchString(epkg.PkgPath) {
+			sdkPkgs = append(sdkPkgs, epkg)
+		}
+	})
 
 	usedSdkMethods := map[SDKMethod]struct{}{}
-	for _, pkg := range pkgs {
-		for _, f := range pkg.Syntax {
-			ast.Inspect(f, func(n ast.Node) bool {
-				call, ok := n.(*ast.CallExpr)
-				if !ok {
-					return true
-				}
-				sel, ok := call.Fun.(*ast.SelectorExpr)
-				if !ok {
-					return true
-				}
-				recvIdent, ok := sel.X.(*ast.Ident)
-				if !ok {
-					return true
-				}
+	visited := map[*ssa.Function]bool{}
+	fns := rootFunctions(pkgs)
+	for len(fns) != 0 {
+		fn := fns[0]
+		fns = fns[1:]
+		// Skip the synthetic functions (e.g. the wrappers of the promoted methods), which are not written by the user.
+		if visited[fn] || fn.Synthetic != "" {
+			continue
+		}
+		visited[fn] = true
+		fns = append(fns, fn.AnonFuncs...)
 
-				recvObj := pkg.TypesInfo.Uses[recvIdent]
-				if !typeutils.IsUnderlyingNamedStruct(recvObj.Type()) {
-					return true
-				}
-				recvType := typeutils.DereferenceR(recvObj.Type()).(*types.Named)
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				for _, op := range instr.Operands(nil) {
+					if op == nil {
+						continue
+					}
+					// The referenced function can also be a synthetic wrapper, i.e. the bound method closure (e.g. "c.Get")
+					// and the method expression thunk (e.g. "(*Client).Get"), whose object is the wrapped method.
+					callee, ok := (*op).(*ssa.Function)
+					if !ok {
+						continue
+					}
+					m, ok := callee.Object().(*types.Func)
+					if !ok {
+						continue
+					}
+					recv := m.Type().(*types.Signature).Recv()
+					if recv == nil || types.IsInterface(recv.Type()) {
+						continue
+					}
+					recvType, ok := typeutils.DereferenceR(recv.Type()).(*types.Named)
+					if !ok {
+						continue
+					}
+					recvType = recvType.Origin()
 
-				// Ensure the receiver is defined in sdk packages
-				recvTypePkg := recvType.Obj().Pkg()
-				if recvTypePkg == nil {
-					return true
-				}
-				if !a.PackagePattern().MatchString(recvTypePkg.Path()) {
-					return true
-				}
+					// Ensure the receiver is defined in sdk packages
+					recvTypePkg := recvType.Obj().Pkg()
+					if recvTypePkg == nil {
+						continue
+					}
+					if !a.PackagePattern().MatchString(recvTypePkg.Path()) {
+						continue
+					}
 
-				pkg, file := typeutils.FindPos(sdkPkgs, recvType.Obj().Pos())
-				if file == nil {
-					panic(fmt.Sprintf("failed to find %q.%q in sdk packages", recvTypePkg.Path(), recvType.Obj().Id()))
-				}
+					sdkPkg, file := typeutils.FindPos(sdkPkgs, recvType.Obj().Pos())
+					if file == nil {
+						if err := diags.Handle(Diagnostic{
+							SDKMethod: fmt.Sprintf("%s.%s.%s", recvTypePkg.Path(), recvType.Obj().Name(), m.Name()),
+							Position:  position(fn.Prog.Fset, instr.Pos()),
+							Reason:    fmt.Sprintf("failed to find %q.%q in sdk packages", recvTypePkg.Path(), recvType.Obj().Id()),
+						}); err != nil {
+							return nil, err
+						}
+						continue
+					}
 
-				m := SDKMethod{
-					Pkg:        pkg,
-					File:       file,
-					Recv:       recvType,
-					MethodName: sel.Sel.Name,
+					usedSdkMethods[SDKMethod{
+						Pkg:        sdkPkg,
+						File:       file,
+						Recv:       recvType,
+						MethodName: m.Name(),
+					}] = struct{}{}
 				}
-
-				usedSdkMethods[m] = struct{}{}
-
-				return true
-			})
+			}
 		}
 	}
-	return usedSdkMethods
+	return usedSdkMethods, nil
 }
 
 func normalizeAPIPath(p string) string {
@@ -206,6 +343,197 @@ func normalizeAPIPath(p string) string {
 	return strings.Join(out, "/")
 }
 
+// apiVersionParam returns the 1-based index of the parameter of the SDK method (methodDecl) that specifies the API version,
+// given the ident that refers to the API version in the helper function (helperDecl, e.g. the preparer). The ident is expected
+// to refer to a parameter of the helper function, which is passed from the SDK method's parameter of the same name.
+// It returns 0 if not found.
+func apiVersionParam(pkg *packages.Package, helperDecl, methodDecl *ast.FuncDecl, ident *ast.Ident) int {
+	obj, ok := pkg.TypesInfo.Uses[ident].(*types.Var)
+	if !ok {
+		return 0
+	}
+	var isParam bool
+	for _, field := range helperDecl.Type.Params.List {
+		for _, name := range field.Names {
+			if pkg.TypesInfo.Defs[name] == obj {
+				isParam = true
+			}
+		}
+	}
+	if !isParam {
+		return 0
+	}
+
+	var idx int
+	for _, field := range methodDecl.Type.Params.List {
+		if len(field.Names) == 0 {
+			idx++
+			continue
+		}
+		for _, name := range field.Names {
+			idx++
+			if name.Name == obj.Name() {
+				return idx
+			}
+		}
+	}
+	return 0
+}
+
+// findAPIVersionParam finds the API version parameter (see apiVersionParam) from the query parameters composite literal, e.g.
+//
+//	map[string]interface{}{
+//		"api-version": APIVersion,
+//	}
+func findAPIVersionParam(pkg *packages.Package, helperDecl, methodDecl *ast.FuncDecl, queryParameters ast.Expr) int {
+	complit, ok := queryParameters.(*ast.CompositeLit)
+	if !ok {
+		return 0
+	}
+	for _, kv := range complit.Elts {
+		kv, ok := kv.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		k, ok := kv.Key.(*ast.BasicLit)
+		if !ok {
+			continue
+		}
+		if klit, _ := strconv.Unquote(k.Value); klit != "api-version" {
+			continue
+		}
+		v, ok := kv.Value.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		return apiVersionParam(pkg, helperDecl, methodDecl, v)
+	}
+	return 0
+}
+
+// setAutoRestRequestDetails sets the request details of the API operation from the autorest preparer function, i.e. the
+// content type (e.g. autorest.AsContentType()), the query parameter keys of the "queryParameters" (excluding the
+// "api-version") and the header names (i.e. autorest.WithHeader()), e.g.
+//
+//	queryParameters := map[string]interface{}{
+//		"api-version": APIVersion,
+//	}
+//	if expand != "" {
+//		queryParameters["$expand"] = autorest.Encode("query", expand)
+//	}
+func setAutoRestRequestDetails(op *APIOperation, pkg *packages.Package, preparerDecl *ast.FuncDecl) {
+	var queryParameters, headers []string
+	addQueryParameter := func(expr ast.Expr) {
+		if k := constString(pkg, expr); k != "" && k != "api-version" {
+			queryParameters = append(queryParameters, k)
+		}
+	}
+	isQueryParameters := func(expr ast.Expr) bool {
+		ident, ok := expr.(*ast.Ident)
+		return ok && ident.Name == "queryParameters"
+	}
+	ast.Inspect(preparerDecl.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+			switch lhs := node.Lhs[0].(type) {
+			case *ast.Ident:
+				if complit, ok := node.Rhs[0].(*ast.CompositeLit); ok && isQueryParameters(lhs) {
+					for _, elt := range complit.Elts {
+						if kv, ok := elt.(*ast.KeyValueExpr); ok {
+							addQueryParameter(kv.Key)
+						}
+					}
+				}
+			case *ast.IndexExpr:
+				if isQueryParameters(lhs.X) {
+					addQueryParameter(lhs.Index)
+				}
+			}
+		case *ast.CallExpr:
+			fun, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			switch fun.Sel.Name {
+			case "AsContentType":
+				if len(node.Args) == 1 {
+					op.ContentType = constString(pkg, node.Args[0])
+				}
+			case "AsJSON":
+				op.ContentType = "application/json; charset=utf-8"
+			case "AsOctetStream":
+				op.ContentType = "application/octet-stream"
+			case "AsFormURLEncoded":
+				op.ContentType = "application/x-www-form-urlencoded"
+			case "WithHeader":
+				if len(node.Args) == 2 {
+					if h := constString(pkg, node.Args[0]); h != "" {
+						headers = append(headers, h)
+					}
+				}
+			}
+		}
+		return true
+	})
+	op.QueryParameters = newStringList(queryParameters)
+	op.Headers = newStringList(headers)
+}
+
+// autorestExpectedStatusCodes returns the expected status codes from the autorest responder function, e.g.
+//
+//	err = autorest.Respond(
+//		resp,
+//		azure.WithErrorUnlessStatusCode(http.StatusOK, http.StatusNoContent),
+//		autorest.ByClosing())
+func autorestExpectedStatusCodes(pkg *packages.Package, responderDecl *ast.FuncDecl) IntList {
+	var codes []int
+	ast.Inspect(responderDecl.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if fun, ok := call.Fun.(*ast.SelectorExpr); !ok || fun.Sel.Name != "WithErrorUnlessStatusCode" {
+			return true
+		}
+		for _, arg := range call.Args {
+			if tv, ok := pkg.TypesInfo.Types[arg]; ok && tv.Value != nil && tv.Value.Kind() == constant.Int {
+				code, _ := constant.Int64Val(tv.Value)
+				codes = append(codes, int(code))
+			}
+		}
+		return false
+	})
+	return newIntList(codes)
+}
+
+// methodDecl returns the declaration of the method of the named type in the package, or nil if not found.
+func methodDecl(pkg *packages.Package, recv *types.Named, methodName string) *ast.FuncDecl {
+	f := typeutils.NamedTypeMethodByName(recv, methodName)
+	if f == nil {
+		return nil
+	}
+	decl, err := typeutils.TypeFunc2DeclarationWithPkg(pkg, f)
+	if err != nil {
+		return nil
+	}
+	return decl
+}
+
+// packageAPIVersion returns the API version of the SDK package, which is defined as a package level constant named
+// "apiVersion" or "defaultApiVersion". It returns "unknown" if not found.
+func packageAPIVersion(sdkpkg *packages.Package) string {
+	for _, name := range []string{"apiVersion", "defaultApiVersion"} {
+		c, ok := sdkpkg.Types.Scope().Lookup(name).(*types.Const)
+		if ok && c.Val().Kind() == constant.String {
+			return constant.StringVal(c.Val())
+		}
+	}
+	return "unknown"
+}
+
 func isSDKFuncLRO(fdecl *ast.FuncDecl, pkg *packages.Package, lroFieldName string) bool {
 	if fdecl.Type.Results == nil || len(fdecl.Type.Results.List) == 0 {
 		return false
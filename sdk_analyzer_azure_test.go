@@ -9,30 +9,69 @@ import (
 
 func TestSDKAnalyzerAzure(t *testing.T) {
 	t.Parallel()
-	pkgs, _, err := loadPackages("./internal/testmodule/azuresdkuser", nil, []string{"."})
+	pkgs, err := loadPackages("./internal/testmodule/azuresdkuser", []string{"."})
 	require.NoError(t, err)
 
 	a := NewSDKAnalyzerAzure(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/azuresdk`))
-	funcs, err := a.FindSDKAPIFuncs(pkgs)
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
 	require.NoError(t, err)
 
 	m := APIOperationMap{}
-	for _, op := range funcs {
-		m[op] = struct{}{}
+	for _, fn := range funcs {
+		m.Add(fn.Operation)
 	}
 	require.Equal(t,
 		APIOperations{
 			{
-				Kind:    OperationKindGet,
-				Version: "2025-04-01",
-				Path:    "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}",
-				IsLRO:   false,
+				Kind:                OperationKindGet,
+				Version:             "2025-04-01",
+				Path:                "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}",
+				ARMPath:             ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"},
+				IsLRO:               false,
+				ExpectedStatusCodes: "200",
 			},
 			{
-				Kind:    OperationKindPut,
-				Version: "2025-04-01",
-				Path:    "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}",
-				IsLRO:   true,
+				Kind:        OperationKindPut,
+				Version:     "2025-04-01",
+				Path:        "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}",
+				ARMPath:     ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"},
+				IsLRO:       true,
+				ContentType: "application/json; charset=utf-8",
+			},
+		},
+		m.ToList())
+}
+
+func TestSDKAnalyzerAzureChained(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/chainedsdkuser", []string{"."})
+	require.NoError(t, err)
+
+	a := NewSDKAnalyzerAzure(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/azuresdk`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+
+	m := APIOperationMap{}
+	for _, fn := range funcs {
+		m.Add(fn.Operation)
+	}
+	require.Equal(t,
+		APIOperations{
+			{
+				Kind:                OperationKindGet,
+				Version:             "2025-04-01",
+				Path:                "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}",
+				ARMPath:             ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"},
+				IsLRO:               false,
+				ExpectedStatusCodes: "200",
+			},
+			{
+				Kind:        OperationKindPut,
+				Version:     "2025-04-01",
+				Path:        "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}",
+				ARMPath:     ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"},
+				IsLRO:       true,
+				ContentType: "application/json; charset=utf-8",
 			},
 		},
 		m.ToList())
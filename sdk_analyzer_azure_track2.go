@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/magodo/aztfo/typeutils"
+)
+
+type SDKAnalyzerAzureTrack2 struct {
+	pattern *regexp.Regexp
+}
+
+// NewSDKAnalyzerAzureTrack2 builds a SDK analyzer for Azure Track2 SDK (i.e. the armXXX packages).
+// The pattern specifies the regexp pattern of these SDK package paths.
+func NewSDKAnalyzerAzureTrack2(pattern *regexp.Regexp) *SDKAnalyzerAzureTrack2 {
+	return &SDKAnalyzerAzureTrack2{
+		pattern: pattern,
+	}
+}
+
+func (a *SDKAnalyzerAzureTrack2) Name() string {
+	return "AzureTrack2"
+}
+
+func (a *SDKAnalyzerAzureTrack2) FindSDKAPIFuncs(pkgs Packages, diags *Diagnostics) (SDKFunctions, error) {
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+	prog := pkgs[0].ssa.Prog
+	usedSdkMethods, err := usedSDKMethods(a, pkgs, diags)
+	if err != nil {
+		return nil, err
+	}
+
+	res := SDKFunctions{}
+	for method := range usedSdkMethods {
+		sdkFunc, err := a.findSDKOperationForMethod(method)
+		if err != nil {
+			if err := diags.Handle(Diagnostic{SDKMethod: sdkMethodName(method), Position: position(method.Pkg.Fset, method.Recv.Obj().Pos()), Reason: err.Error()}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if sdkFunc == nil {
+			continue
+		}
+
+		// The Track2 SDK clients are defined with pointer receivers.
+		ssaFunc := prog.LookupMethod(types.NewPointer(method.Recv), method.Pkg.Types, method.MethodName)
+		if ssaFunc == nil {
+			return nil, fmt.Errorf("failed to find the ssa function of %s.%s", method.Recv.Obj().Id(), method.MethodName)
+		}
+
+		res[ssaFunc] = *sdkFunc
+	}
+
+	return res, nil
+}
+
+// findSDKOperationForMethod tries to find a method in the same receiver of the used SDK method that is named after "CreateRequest"
+// (as it contains the information we are interested in). If not found, returns nil APIOperation. E.g.
+//
+//   - Get                   -> getCreateRequest
+//   - BeginCreateOrUpdate   -> createOrUpdateCreateRequest
+//   - NewListByParentPager  -> listByParentCreateRequest
+func (a *SDKAnalyzerAzureTrack2) findSDKOperationForMethod(method SDKMethod) (*SDKFunction, error) {
+	var isLRO bool
+	methodName := method.MethodName
+	if strings.HasPrefix(methodName, "Begin") {
+		methodName = strings.TrimPrefix(methodName, "Begin")
+		isLRO = true
+	} else if strings.HasPrefix(methodName, "New") && strings.HasSuffix(methodName, "Pager") {
+		methodName = strings.TrimSuffix(strings.TrimPrefix(methodName, "New"), "Pager")
+	}
+	if methodName == "" {
+		return nil, nil
+	}
+	r, size := utf8.DecodeRuneInString(methodName)
+	createRequestMethod := string(unicode.ToLower(r)) + methodName[size:] + "CreateRequest"
+
+	f := typeutils.NamedTypeMethodByName(method.Recv, createRequestMethod)
+	if f == nil {
+		return nil, nil
+	}
+	createRequestMethodDecl, err := typeutils.TypeFunc2DeclarationWithPkg(method.Pkg, f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the declaration of %s.%s", method.Recv.Obj().Id(), createRequestMethod)
+	}
+
+	thisMethod := typeutils.NamedTypeMethodByName(method.Recv, method.MethodName)
+	if thisMethod == nil {
+		return nil, fmt.Errorf("failed to find the function type of %s.%s", method.Recv.Obj().Id(), method.MethodName)
+	}
+	thisMethodDecl, err := typeutils.TypeFunc2DeclarationWithPkg(method.Pkg, thisMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the declaration of %s.%s", method.Recv.Obj().Id(), method.MethodName)
+	}
+
+	// Analyze the create request function and gather the interested information.
+	var (
+		apiVersion   string
+		versionParam int
+		apiPath      string
+		opKind       OperationKind
+	)
+
+	ast.Inspect(createRequestMethodDecl.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		// Looking for api path, e.g.
+		//
+		// urlPath := "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Foo/foos/{fooName}"
+		case *ast.AssignStmt:
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+			lIdent, ok := node.Lhs[0].(*ast.Ident)
+			if !ok || lIdent.Name != "urlPath" {
+				return true
+			}
+			lit, ok := node.Rhs[0].(*ast.BasicLit)
+			if !ok {
+				return true
+			}
+			apiPath, _ = strconv.Unquote(lit.Value)
+			return false
+		// Looking for the operation kind and api version, e.g.
+		//
+		// req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.internal.Endpoint(), urlPath))
+		// reqQP.Set("api-version", "2025-04-01")
+		case *ast.CallExpr:
+			fun, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			switch fun.Sel.Name {
+			case "NewRequest":
+				if len(node.Args) < 2 {
+					return true
+				}
+				sel, ok := node.Args[1].(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				switch sel.Sel.Name {
+				case "MethodGet":
+					opKind = OperationKindGet
+				case "MethodPost":
+					opKind = OperationKindPost
+				case "MethodPut":
+					opKind = OperationKindPut
+				case "MethodDelete":
+					opKind = OperationKindDelete
+				case "MethodHead":
+					opKind = OperationKindHead
+				case "MethodPatch":
+					opKind = OperationKindPatch
+				case "MethodOptions":
+					opKind = OperationKindOptions
+				}
+			case "Set":
+				if len(node.Args) != 2 {
+					return true
+				}
+				k, ok := node.Args[0].(*ast.BasicLit)
+				if !ok {
+					return true
+				}
+				if klit, _ := strconv.Unquote(k.Value); klit != "api-version" {
+					return true
+				}
+				switch v := node.Args[1].(type) {
+				case *ast.BasicLit:
+					apiVersion, _ = strconv.Unquote(v.Value)
+				case *ast.Ident:
+					if c, ok := method.Pkg.TypesInfo.Uses[v].(*types.Const); ok {
+						apiVersion = constant.StringVal(c.Val())
+					} else {
+						// The API version is passed by the caller, e.g. armresources.Client.
+						versionParam = apiVersionParam(method.Pkg, createRequestMethodDecl, thisMethodDecl, v)
+					}
+				}
+			}
+			return true
+		default:
+			return true
+		}
+	})
+
+	var finalStateVia string
+	if isLRO {
+		finalStateVia = findFinalStateVia(thisMethodDecl)
+	}
+
+	// Some API (e.g. armresources.Client) can accept the APIVersion as a parameter, which is resolved at its call sites.
+	if apiVersion == "" {
+		apiVersion = "unknown"
+	}
+	var diags []string
+	if apiPath == "" {
+		diags = append(diags, "api path is not found")
+	}
+	if opKind == "" {
+		diags = append(diags, "API operation kind is not found")
+	}
+	if len(diags) != 0 {
+		return nil, fmt.Errorf("SDK operation info of the %s.%s is not complete: %s", method.Recv.Obj().Id(), createRequestMethod, strings.Join(diags, ","))
+	}
+
+	return &SDKFunction{
+		Operation: APIOperation{
+			Kind:    opKind,
+			Version: apiVersion,
+			Path:    normalizeAPIPath(apiPath),
+			ARMPath: parseARMPath(apiPath, opKind),
+			IsLRO:   isLRO,
+		},
+		versionParam:  versionParam,
+		finalStateVia: finalStateVia,
+	}, nil
+}
+
+// findFinalStateVia finds where the poller fetches the final state of the LRO from, in the poller options of the BeginXXX
+// method, e.g.
+//
+//	runtime.NewPoller(resp, client.internal.Pipeline(), &runtime.NewPollerOptions[FoosClientCreateOrUpdateResponse]{
+//		FinalStateVia: runtime.FinalStateViaAzureAsyncOp,
+//	})
+//
+// It returns empty string if not found.
+func findFinalStateVia(methodDecl *ast.FuncDecl) string {
+	var via string
+	ast.Inspect(methodDecl.Body, func(node ast.Node) bool {
+		kv, ok := node.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+		k, ok := kv.Key.(*ast.Ident)
+		if !ok || k.Name != "FinalStateVia" {
+			return true
+		}
+		v, ok := kv.Value.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		switch v.Sel.Name {
+		case "FinalStateViaAzureAsyncOp":
+			via = FinalStateViaAzureAsyncOperation
+		case "FinalStateViaLocation":
+			via = FinalStateViaLocation
+		case "FinalStateViaOriginalURI":
+			via = FinalStateViaOriginalURI
+		case "FinalStateViaOpLocation":
+			via = FinalStateViaOperationLocation
+		}
+		return false
+	})
+	return via
+}
+
+func (a *SDKAnalyzerAzureTrack2) PackagePattern() *regexp.Regexp {
+	return a.pattern
+}
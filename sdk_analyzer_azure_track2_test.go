@@ -0,0 +1,53 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDKAnalyzerAzureTrack2(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/azuresdktrack2user", []string{"."})
+	require.NoError(t, err)
+
+	a := NewSDKAnalyzerAzureTrack2(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/azuresdktrack2`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+
+	m := APIOperationMap{}
+	for _, fn := range funcs {
+		m.Add(fn.Operation)
+	}
+	require.Equal(t,
+		APIOperations{
+			{
+				Kind:    OperationKindGet,
+				Version: "2025-04-01",
+				Path:    "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS",
+				ARMPath: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"},
+				IsLRO:   false,
+			},
+			{
+				Kind:    OperationKindGet,
+				Version: "2025-04-01",
+				Path:    "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}",
+				ARMPath: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"},
+				IsLRO:   false,
+			},
+			{
+				Kind:    OperationKindPut,
+				Version: "2025-04-01",
+				Path:    "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}",
+				ARMPath: ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"},
+				IsLRO:   true,
+			},
+		},
+		m.ToList())
+	for _, fn := range funcs {
+		if fn.Operation.IsLRO {
+			require.Equal(t, FinalStateViaAzureAsyncOperation, fn.finalStateVia)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/magodo/aztfo/typeutils"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+)
+
+type SDKAnalyzerCustom struct {
+	pattern *regexp.Regexp
+}
+
+// NewSDKAnalyzerCustom builds a SDK analyzer for the custom SDKs that are hand-written in the provider, on top of the base
+// layer of the "github.com/hashicorp/go-azure-sdk/sdk" (i.e. client.RequestOptions) or the autorest (i.e. the preparers).
+// The pattern specifies the regexp pattern of the custom SDK package path. A nil pattern matches the packages of the
+// provider's own module (i.e. the main module), so that the custom SDKs are found wherever they are placed.
+func NewSDKAnalyzerCustom(pattern *regexp.Regexp) *SDKAnalyzerCustom {
+	return &SDKAnalyzerCustom{
+		pattern: pattern,
+	}
+}
+
+func (a *SDKAnalyzerCustom) Name() string {
+	return "Custom"
+}
+
+// PackagePattern returns the pattern of the custom SDK package path, which is nil for the provider's own module.
+func (a *SDKAnalyzerCustom) PackagePattern() *regexp.Regexp {
+	return a.pattern
+}
+
+func (a *SDKAnalyzerCustom) match(pkg *packages.Package) bool {
+	if a.pattern == nil {
+		return pkg.Module != nil && pkg.Module.Main
+	}
+	return a.pattern.MatchString(pkg.PkgPath)
+}
+
+// FindSDKAPIFuncs finds the functions defined in the custom SDK packages that construct a client.RequestOptions or an
+// autorest preparer, regardless of their names and receivers. Unlike the other SDK analyzers, the functions are not
+// required to be called by the "pkgs" directly, as they are mostly called by the other functions of the custom SDK.
+func (a *SDKAnalyzerCustom) FindSDKAPIFuncs(pkgs Packages, diags *Diagnostics) (SDKFunctions, error) {
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+	prog := pkgs[0].ssa.Prog
+
+	var sdkPkgs []*packages.Package
+	packages.Visit(pkgs.Pkgs(), nil, func(pkg *packages.Package) {
+		if a.match(pkg) {
+			sdkPkgs = append(sdkPkgs, pkg)
+		}
+	})
+
+	res := SDKFunctions{}
+	for _, pkg := range sdkPkgs {
+		for _, f := range pkg.Syntax {
+			for _, decl := range f.Decls {
+				fdecl, ok := decl.(*ast.FuncDecl)
+				if !ok || fdecl.Body == nil {
+					continue
+				}
+				fobj, ok := pkg.TypesInfo.Defs[fdecl.Name].(*types.Func)
+				if !ok {
+					continue
+				}
+				ssaFunc := prog.FuncValue(fobj)
+				if ssaFunc == nil {
+					// e.g. the generic functions
+					continue
+				}
+
+				apiOp, err := a.findSDKOperationForFunc(pkg, ssaFunc, fdecl)
+				if err != nil {
+					if err := diags.Handle(Diagnostic{SDKMethod: customSDKFuncName(fobj), Position: position(pkg.Fset, fdecl.Pos()), Reason: fmt.Sprintf("failed to find SDK operation: %v", err)}); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				if apiOp == nil {
+					continue
+				}
+
+				res[ssaFunc] = SDKFunction{Operation: *apiOp}
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// findSDKOperationForFunc finds the client.RequestOptions composite literal, or the autorest.CreatePreparer call in the
+// function. If neither is found, returns nil APIOperation.
+func (a *SDKAnalyzerCustom) findSDKOperationForFunc(pkg *packages.Package, fn *ssa.Function, fdecl *ast.FuncDecl) (*APIOperation, error) {
+	var (
+		comp     *ast.CompositeLit
+		preparer *ast.CallExpr
+	)
+	ast.Inspect(fdecl.Body, func(n ast.Node) bool {
+		if comp != nil || preparer != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			if isNamedType(pkg.TypesInfo.TypeOf(n), "github.com/hashicorp/go-azure-sdk/sdk/client", "RequestOptions") {
+				comp = n
+				return false
+			}
+		case *ast.CallExpr:
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "CreatePreparer" {
+				if f, ok := pkg.TypesInfo.Uses[sel.Sel].(*types.Func); ok && f.Pkg() != nil && f.Pkg().Path() == "github.com/Azure/go-autorest/autorest" {
+					preparer = n
+					return false
+				}
+			}
+		}
+		return true
+	})
+
+	var (
+		op  APIOperation
+		err error
+	)
+	switch {
+	case comp != nil:
+		op, err = requestOptionsOperation(pkg, fn, comp)
+		if err != nil {
+			return nil, err
+		}
+		op.Version = packageAPIVersion(pkg)
+		op.IsLRO = isSDKFuncLRO(fdecl, pkg, "Poller")
+	case preparer != nil:
+		var apiPath string
+		op.Kind, apiPath, err = autorestPreparerOperation(pkg, fn, preparer)
+		if err != nil {
+			return nil, err
+		}
+		op.Path = normalizeAPIPath(apiPath)
+		op.ARMPath = parseARMPath(apiPath, op.Kind)
+		op.Version = autorestAPIVersion(pkg, fdecl)
+		setAutoRestRequestDetails(&op, pkg, fdecl)
+		if recv, methodName := autorestMethod(pkg, fdecl); recv != nil {
+			if decl := methodDecl(pkg, recv, methodName); decl != nil {
+				op.IsLRO = isSDKFuncLRO(decl, pkg, "FutureAPI") || isSDKFuncLRO(decl, pkg, "Poller")
+			}
+			for _, responder := range []string{methodName + "Responder", "responderFor" + methodName} {
+				if decl := methodDecl(pkg, recv, responder); decl != nil {
+					op.ExpectedStatusCodes = autorestExpectedStatusCodes(pkg, decl)
+				}
+			}
+		}
+	default:
+		return nil, nil
+	}
+
+	var diags []string
+	if op.Path == "" {
+		diags = append(diags, "api path is not found")
+	}
+	if op.Kind == "" {
+		diags = append(diags, "API operation kind is not found")
+	}
+	if len(diags) != 0 {
+		return nil, fmt.Errorf("SDK operation info of the %s is not complete: %s", fdecl.Name.Name, strings.Join(diags, ","))
+	}
+
+	return &op, nil
+}
+
+// autorestMethod returns the receiver and the name of the method that calls the autorest preparer method. The preparer
+// method is named after either "<Method>Preparer" (Track1 SDK) or "preparerFor<Method>" (Hashicorp SDK), on the same
+// receiver. It returns nil receiver if the preparer is not such a method.
+func autorestMethod(pkg *packages.Package, fdecl *ast.FuncDecl) (*types.Named, string) {
+	fobj, ok := pkg.TypesInfo.Defs[fdecl.Name].(*types.Func)
+	if !ok {
+		return nil, ""
+	}
+	recv := fobj.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return nil, ""
+	}
+	recvType, ok := typeutils.DereferenceR(recv.Type()).(*types.Named)
+	if !ok {
+		return nil, ""
+	}
+	switch name := fdecl.Name.Name; {
+	case strings.HasSuffix(name, "Preparer"):
+		return recvType, strings.TrimSuffix(name, "Preparer")
+	case strings.HasPrefix(name, "preparerFor"):
+		return recvType, strings.TrimPrefix(name, "preparerFor")
+	default:
+		return nil, ""
+	}
+}
+
+// autorestPreparerOperation returns the operation kind and the API path (in its original casing) from the arguments of the
+// autorest.CreatePreparer call, e.g.
+//
+//	autorest.CreatePreparer(
+//		autorest.AsGet(),
+//		autorest.WithBaseURL(client.BaseURI),
+//		autorest.WithPathParameters("/subscriptions/{subscriptionId}/providers/Microsoft.Foo/foos/{fooName}", pathParameters),
+//		autorest.WithQueryParameters(queryParameters))
+func autorestPreparerOperation(pkg *packages.Package, fn *ssa.Function, call *ast.CallExpr) (OperationKind, string, error) {
+	var (
+		opKind  OperationKind
+		apiPath string
+	)
+	for _, arg := range call.Args {
+		callexpr, ok := arg.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		fun, ok := callexpr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		switch fun.Sel.Name {
+		case "WithPathParameters",
+			"WithPath":
+			if len(callexpr.Args) == 0 {
+				continue
+			}
+			var err error
+			apiPath, err = apiPathFromCallArg(pkg, fn, callexpr)
+			if err != nil {
+				return "", "", err
+			}
+		case "AsGet":
+			opKind = OperationKindGet
+		case "AsPut":
+			opKind = OperationKindPut
+		case "AsPost":
+			opKind = OperationKindPost
+		case "AsDelete":
+			opKind = OperationKindDelete
+		case "AsOption":
+			opKind = OperationKindOptions
+		case "AsHead":
+			opKind = OperationKindHead
+		case "AsPatch":
+			opKind = OperationKindPatch
+		}
+	}
+	return opKind, apiPath, nil
+}
+
+// autorestAPIVersion returns the API version of the autorest preparer function, which is the constant value of the
+// "api-version" in the query parameters composite literal, e.g.
+//
+//	queryParameters := map[string]interface{}{
+//		"api-version": APIVersion,
+//	}
+//
+// It falls back to the API version of the package (see packageAPIVersion).
+func autorestAPIVersion(pkg *packages.Package, fdecl *ast.FuncDecl) string {
+	var apiVersion string
+	ast.Inspect(fdecl.Body, func(n ast.Node) bool {
+		if apiVersion != "" {
+			return false
+		}
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+		k, ok := kv.Key.(*ast.BasicLit)
+		if !ok {
+			return true
+		}
+		if klit, _ := strconv.Unquote(k.Value); klit != "api-version" {
+			return true
+		}
+		if tv, ok := pkg.TypesInfo.Types[kv.Value]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			apiVersion = constant.StringVal(tv.Value)
+		}
+		return false
+	})
+	if apiVersion == "" {
+		apiVersion = packageAPIVersion(pkg)
+	}
+	return apiVersion
+}
+
+// isNamedType tells whether the (pointer) type t is the named type of the package path and name.
+func isNamedType(t types.Type, pkgPath, name string) bool {
+	if t == nil {
+		return false
+	}
+	named, ok := typeutils.DereferenceR(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+// customSDKFuncName returns the name of the custom SDK function used in the diagnostics, e.g. "pkg.FooClient.Get" or "pkg.getFoo".
+func customSDKFuncName(f *types.Func) string {
+	if recv := f.Type().(*types.Signature).Recv(); recv != nil {
+		if named, ok := typeutils.DereferenceR(recv.Type()).(*types.Named); ok {
+			return fmt.Sprintf("%s.%s.%s", f.Pkg().Path(), named.Obj().Name(), f.Name())
+		}
+	}
+	return fmt.Sprintf("%s.%s", f.Pkg().Path(), f.Name())
+}
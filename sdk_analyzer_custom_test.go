@@ -0,0 +1,70 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDKAnalyzerCustom(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/customsdkuser", []string{"."})
+	require.NoError(t, err)
+
+	a := NewSDKAnalyzerCustom(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/customsdkuser/sdk$`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+
+	names := map[string]APIOperation{}
+	for f, fn := range funcs {
+		names[f.RelString(f.Pkg.Pkg)] = fn.Operation
+	}
+	require.Equal(t,
+		map[string]APIOperation{
+			"(BarClient).getOptions": {
+				Kind:                OperationKindGet,
+				Version:             "2025-05-01",
+				Path:                "/SUBSCRIPTIONS/{}/PROVIDERS/MICROSOFT.FOO/BARS/{}",
+				ARMPath:             ARMPath{Scope: ARMScopeSubscription, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "bars"},
+				ContentType:         "application/json; charset=utf-8",
+				ExpectedStatusCodes: "200",
+			},
+			"DeleteBar": {
+				Kind:                OperationKindDelete,
+				Version:             "2025-05-01",
+				Path:                "/PROVIDERS/MICROSOFT.FOO/BARS/{}",
+				ARMPath:             ARMPath{Scope: ARMScopeTenant, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "bars"},
+				ExpectedStatusCodes: "200",
+			},
+			"(BazClient).GetPreparer": {
+				Kind:                OperationKindGet,
+				Version:             "2025-06-01",
+				Path:                "/PROVIDERS/MICROSOFT.FOO/BAZS/{}",
+				ARMPath:             ARMPath{Scope: ARMScopeTenant, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "bazs"},
+				QueryParameters:     "$expand",
+				Headers:             "If-None-Match",
+				ExpectedStatusCodes: "200,304",
+			},
+		},
+		names)
+}
+
+func TestSDKAnalyzerCustomMainModule(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/customsdkuser", []string{"."})
+	require.NoError(t, err)
+
+	// Without the pattern, the custom SDK functions are found in any package of the main module.
+	funcs, err := NewSDKAnalyzerCustom(nil).FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+	var names []string
+	for f := range funcs {
+		names = append(names, f.RelString(nil))
+	}
+	require.ElementsMatch(t, []string{
+		"(github.com/magodo/aztfo/internal/testmodule/customsdkuser/sdk.BarClient).getOptions",
+		"github.com/magodo/aztfo/internal/testmodule/customsdkuser/sdk.DeleteBar",
+		"(github.com/magodo/aztfo/internal/testmodule/customsdkuser/sdk.BazClient).GetPreparer",
+	}, names)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+	"strings"
+
+	"github.com/magodo/aztfo/typeutils"
+	"golang.org/x/tools/go/ssa"
+)
+
+type SDKAnalyzerDataPlane struct {
+	pattern *regexp.Regexp
+}
+
+// NewSDKAnalyzerDataPlane builds a SDK analyzer for the data-plane SDKs that are built on top of the base client of the
+// "github.com/hashicorp/go-azure-sdk/sdk", e.g. the "github.com/tombuildsstuff/giovanni" storage SDK.
+// The pattern specifies the regexp pattern of the SDK package path.
+func NewSDKAnalyzerDataPlane(pattern *regexp.Regexp) *SDKAnalyzerDataPlane {
+	return &SDKAnalyzerDataPlane{
+		pattern: pattern,
+	}
+}
+
+func (a *SDKAnalyzerDataPlane) Name() string {
+	return "DataPlane"
+}
+
+func (a *SDKAnalyzerDataPlane) PackagePattern() *regexp.Regexp {
+	return a.pattern
+}
+
+func (a *SDKAnalyzerDataPlane) FindSDKAPIFuncs(pkgs Packages, diags *Diagnostics) (SDKFunctions, error) {
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+	prog := pkgs[0].ssa.Prog
+	usedSdkMethods, err := usedSDKMethods(a, pkgs, diags)
+	if err != nil {
+		return nil, err
+	}
+
+	res := SDKFunctions{}
+	for method := range usedSdkMethods {
+		apiOp, err := a.findSDKOperationForMethod(prog, method)
+		if err != nil {
+			if err := diags.Handle(Diagnostic{SDKMethod: sdkMethodName(method), Position: position(method.Pkg.Fset, method.Recv.Obj().Pos()), Reason: fmt.Sprintf("failed to find SDK operation: %v", err)}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if apiOp == nil {
+			continue
+		}
+
+		ssaFunc := prog.LookupMethod(method.Recv, method.Pkg.Types, method.MethodName)
+		if ssaFunc == nil {
+			return nil, fmt.Errorf("failed to find the ssa function of %s.%s", method.Recv.Obj().Id(), method.MethodName)
+		}
+
+		res[ssaFunc] = SDKFunction{Operation: *apiOp}
+	}
+
+	return res, nil
+}
+
+// findSDKOperationForMethod finds the client.RequestOptions composite literal in the used SDK method. Unlike the
+// resource manager SDK, it is not necessarily the first statement, as the data-plane SDK methods mostly validate the
+// arguments first.
+// If not found, returns nil APIOperation.
+func (a *SDKAnalyzerDataPlane) findSDKOperationForMethod(prog *ssa.Program, method SDKMethod) (*APIOperation, error) {
+	sdkFunc := typeutils.NamedTypeMethodByName(method.Recv, method.MethodName)
+	if sdkFunc == nil {
+		return nil, nil
+	}
+	sdkFuncDecl, err := typeutils.TypeFunc2DeclarationWithPkg(method.Pkg, sdkFunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the declaration of %s.%s", method.Recv.Obj().Id(), method.MethodName)
+	}
+
+	var comp *ast.CompositeLit
+	ast.Inspect(sdkFuncDecl.Body, func(n ast.Node) bool {
+		if comp != nil {
+			return false
+		}
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		if sel, ok := lit.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "RequestOptions" {
+			if x, ok := sel.X.(*ast.Ident); ok && x.Name == "client" {
+				comp = lit
+			}
+		}
+		return false
+	})
+	if comp == nil {
+		return nil, nil
+	}
+
+	op, err := requestOptionsOperation(method.Pkg, prog.FuncValue(sdkFunc), comp)
+	if err != nil {
+		return nil, err
+	}
+
+	var diags []string
+	if op.Path == "" {
+		diags = append(diags, "api path is not found")
+	}
+	if op.Kind == "" {
+		diags = append(diags, "API operation kind is not found")
+	}
+	if len(diags) != 0 {
+		return nil, fmt.Errorf("SDK operation info of the %s.%s is not complete: %s", method.Recv.Obj().Id(), method.MethodName,
+			strings.Join(diags, ","))
+	}
+
+	op.Version = packageAPIVersion(method.Pkg)
+	op.IsLRO = isSDKFuncLRO(sdkFuncDecl, method.Pkg, "Poller")
+	return &op, nil
+}
+
+// dataPlaneSDKAnalyzer wraps a SDK analyzer, to mark the API operations found by it as the data-plane operations of
+// the endpoint. This applies to both the SDKAnalyzerDataPlane, and the other analyzers that analyze a data-plane SDK,
+// e.g. the Track1 SDK analyzer for the Key Vault SDK of "github.com/jackofallops/kermit".
+type dataPlaneSDKAnalyzer struct {
+	SDKAnalyzer
+	endpoint string
+}
+
+func (a dataPlaneSDKAnalyzer) FindSDKAPIFuncs(pkgs Packages, diags *Diagnostics) (SDKFunctions, error) {
+	funcs, err := a.SDKAnalyzer.FindSDKAPIFuncs(pkgs, diags)
+	if err != nil {
+		return nil, err
+	}
+	for f, fn := range funcs {
+		fn.Operation.Plane = PlaneData
+		fn.Operation.Endpoint = a.endpoint
+		fn.Operation.ARMPath = ARMPath{}
+		funcs[f] = fn
+	}
+	return funcs, nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDKAnalyzerDataPlane(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/dataplanesdkuser", []string{"."})
+	require.NoError(t, err)
+
+	a := NewSDKAnalyzerDataPlane(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/dataplanesdk/blob/`))
+	funcs, err := dataPlaneSDKAnalyzer{SDKAnalyzer: a, endpoint: "https://{}.blob.core.windows.net"}.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+	m := APIOperationMap{}
+	for _, fn := range funcs {
+		m.Add(fn.Operation)
+	}
+	require.Equal(t,
+		APIOperations{
+			{Kind: OperationKindGet, Version: "2023-11-03", Path: "/{}", Plane: PlaneData, Endpoint: "https://{}.blob.core.windows.net", ExpectedStatusCodes: "200"},
+			{Kind: OperationKindPut, Version: "2023-11-03", Path: "/{}", Plane: PlaneData, Endpoint: "https://{}.blob.core.windows.net", ExpectedStatusCodes: "201"},
+		},
+		m.ToList())
+
+	// The Track1 SDK analyzer for the data-plane SDK
+	kv := NewSDKAnalyzerAzure(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/kermitsdk/keyvault`))
+	funcs, err = dataPlaneSDKAnalyzer{SDKAnalyzer: kv, endpoint: "https://{}.vault.azure.net"}.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+	m = APIOperationMap{}
+	for _, fn := range funcs {
+		m.Add(fn.Operation)
+	}
+	require.Equal(t,
+		APIOperations{
+			{Kind: OperationKindGet, Version: "7.4", Path: "/SECRETS/{}/{}", Plane: PlaneData, Endpoint: "https://{}.vault.azure.net", ExpectedStatusCodes: "200"},
+		},
+		m.ToList())
+}
@@ -16,27 +16,14 @@ import (
 )
 
 type SDKAnalyzerHashicorp struct {
-	pattern      *regexp.Regexp
-	commonidsPkg *packages.Package
+	pattern *regexp.Regexp
 }
 
 // NewSDKAnalyzerHashicorp builds a SDK analyzer for Hashicorp SDK.
 // The pattern specifies the regexp pattern of the SDK package path.
-func NewSDKAnalyzerHashicorp(pattern *regexp.Regexp, pkgs []*packages.Package) *SDKAnalyzerHashicorp {
-	// "hashicorp/go-azure-helpers/resourcemanager/commonids" package is needed for constructing *some* resource ids, which is used later by this analyzer.
-	var commonidsPkg *packages.Package
-out:
-	for _, pkg := range pkgs {
-		for _, epkg := range pkg.Imports {
-			if epkg.PkgPath == "github.com/hashicorp/go-azure-helpers/resourcemanager/commonids" {
-				commonidsPkg = epkg
-				break out
-			}
-		}
-	}
+func NewSDKAnalyzerHashicorp(pattern *regexp.Regexp) *SDKAnalyzerHashicorp {
 	return &SDKAnalyzerHashicorp{
-		pattern:      pattern,
-		commonidsPkg: commonidsPkg,
+		pattern: pattern,
 	}
 }
 
@@ -44,14 +31,17 @@ func (a *SDKAnalyzerHashicorp) Name() string {
 	return "Hashicorp"
 }
 
-func (a *SDKAnalyzerHashicorp) FindSDKAPIFuncs(pkgs Packages) (map[*ssa.Function]APIOperation, error) {
+func (a *SDKAnalyzerHashicorp) FindSDKAPIFuncs(pkgs Packages, diags *Diagnostics) (SDKFunctions, error) {
 	if len(pkgs) == 0 {
 		return nil, nil
 	}
 	prog := pkgs[0].ssa.Prog
-	usedSdkMethods := usedSDKMethods(a, pkgs.Pkgs())
+	usedSdkMethods, err := usedSDKMethods(a, pkgs, diags)
+	if err != nil {
+		return nil, err
+	}
 
-	res := map[*ssa.Function]APIOperation{}
+	res := SDKFunctions{}
 	for method := range usedSdkMethods {
 		isAutoRestImported := func(imports []*ast.ImportSpec) bool {
 			for _, ipt := range imports {
@@ -62,30 +52,36 @@ func (a *SDKAnalyzerHashicorp) FindSDKAPIFuncs(pkgs Packages) (map[*ssa.Function
 			return false
 		}
 		var (
-			apiOp *APIOperation
-			err   error
+			sdkFunc *SDKFunction
+			err     error
 		)
 		if isAutoRestImported(method.File.Imports) {
-			apiOp, err = a.findSDKOperationForMethodAutoRest(method)
+			sdkFunc, err = a.findSDKOperationForMethodAutoRest(prog, method)
 			if err != nil {
-				return nil, fmt.Errorf("failed to find SDK operation (autorest) for method %s.%s: %v", method.Recv.Obj().Id(), method.MethodName, err)
+				err = fmt.Errorf("failed to find SDK operation (autorest): %v", err)
 			}
 		} else {
-			apiOp, err = a.findSDKOperationForMethodNative(method)
+			sdkFunc, err = a.findSDKOperationForMethodNative(prog, method)
 			if err != nil {
-				return nil, fmt.Errorf("failed to find SDK operation (native) for method %s.%s: %v", method.Recv.Obj().Id(), method.MethodName, err)
+				err = fmt.Errorf("failed to find SDK operation (native): %v", err)
 			}
 		}
-		if apiOp == nil {
+		if err != nil {
+			if err := diags.Handle(Diagnostic{SDKMethod: sdkMethodName(method), Position: position(method.Pkg.Fset, method.Recv.Obj().Pos()), Reason: err.Error()}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if sdkFunc == nil {
 			continue
 		}
 
 		ssaFunc := prog.LookupMethod(method.Recv, method.Pkg.Types, method.MethodName)
 		if ssaFunc == nil {
-			return nil, fmt.Errorf("failed to find the ssa function of %s.%s: %v", method.Recv.Obj().Id(), method.MethodName, err)
+			return nil, fmt.Errorf("failed to find the ssa function of %s.%s", method.Recv.Obj().Id(), method.MethodName)
 		}
 
-		res[ssaFunc] = *apiOp
+		res[ssaFunc] = *sdkFunc
 	}
 
 	return res, nil
@@ -93,7 +89,7 @@ func (a *SDKAnalyzerHashicorp) FindSDKAPIFuncs(pkgs Packages) (map[*ssa.Function
 
 // findSDKOperationForMethodAutoRest finds the autorest transport based method on the same receiver of the used SDK method, named after "preparerFor".
 // If not found, returns nil APIOperation.
-func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodAutoRest(method SDKMethod) (*APIOperation, error) {
+func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodAutoRest(prog *ssa.Program, method SDKMethod) (*SDKFunction, error) {
 	var isLRO bool
 	methodName := method.MethodName
 	if strings.HasSuffix(methodName, "ThenPoll") {
@@ -120,31 +116,35 @@ func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodAutoRest(method SDKMetho
 		return nil, fmt.Errorf("failed to find the declaration of %s.%s", method.Recv.Obj().Id(), preparerMethod)
 	}
 
+	thisMethod := typeutils.NamedTypeMethodByName(method.Recv, method.MethodName)
+	if thisMethod == nil {
+		return nil, nil
+	}
+	thisMethodDecl, err := typeutils.TypeFunc2DeclarationWithPkg(method.Pkg, thisMethod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the declaration of %s.%s", method.Recv.Obj().Id(), method.MethodName)
+	}
 	if !isLRO {
-		thisMethod := typeutils.NamedTypeMethodByName(method.Recv, method.MethodName)
-		if thisMethod == nil {
-			return nil, nil
-		}
-		thisMethodDecl, err := typeutils.TypeFunc2DeclarationWithPkg(method.Pkg, thisMethod)
-		if err != nil {
-			return nil, fmt.Errorf("failed to find the declaration of %s.%s", method.Recv.Obj().Id(), method.MethodName)
-		}
 		isLRO = isSDKFuncLRO(thisMethodDecl, method.Pkg, "Poller")
 	}
 
 	// Analyze the preparer function and gather the interested information.
 	var (
-		apiVersion string
-		apiPath    string
-		opKind     OperationKind
+		apiVersion   string
+		versionParam int
+		apiPath      string
+		opKind       OperationKind
 	)
 
 	ast.Inspect(prepareFuncDecl.Body, func(node ast.Node) bool {
+		if err != nil {
+			return false
+		}
 		switch node := node.(type) {
 		// Looking for api path, version and operation kind
 		case *ast.AssignStmt:
 			lhs := node.Lhs
-			if len(lhs) != 1 {
+			if len(lhs) != 1 || len(node.Rhs) != 1 {
 				return false
 			}
 			lIdent, ok := lhs[0].(*ast.Ident)
@@ -155,9 +155,15 @@ func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodAutoRest(method SDKMetho
 			switch lIdent.Name {
 			// API version
 			case "queryParameters":
-				kvs := node.Rhs[0].(*ast.CompositeLit).Elts
-				for _, kv := range kvs {
-					kv := kv.(*ast.KeyValueExpr)
+				complit, ok := node.Rhs[0].(*ast.CompositeLit)
+				if !ok {
+					return false
+				}
+				for _, kv := range complit.Elts {
+					kv, ok := kv.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
 					k, ok := kv.Key.(*ast.BasicLit)
 					if !ok {
 						continue
@@ -170,16 +176,23 @@ func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodAutoRest(method SDKMetho
 					if !ok {
 						continue
 					}
-					apiVersionObj, ok := method.Pkg.TypesInfo.Uses[v]
+					apiVersionObj, ok := method.Pkg.TypesInfo.Uses[v].(*types.Const)
 					if !ok {
 						continue
 					}
-					apiVersion = constant.StringVal(apiVersionObj.(*types.Const).Val())
+					apiVersion = constant.StringVal(apiVersionObj.Val())
+				}
+				if apiVersion == "" {
+					versionParam = findAPIVersionParam(method.Pkg, prepareFuncDecl, thisMethodDecl, complit)
 				}
 				return false
 			// API Path and Operation kind
 			case "preparer":
-				for _, arg := range node.Rhs[0].(*ast.CallExpr).Args {
+				call, ok := node.Rhs[0].(*ast.CallExpr)
+				if !ok {
+					return false
+				}
+				for _, arg := range call.Args {
 					callexpr, ok := arg.(*ast.CallExpr)
 					if !ok {
 						continue
@@ -191,33 +204,13 @@ func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodAutoRest(method SDKMetho
 					switch fun.Sel.Name {
 					case "WithPathParameters",
 						"WithPath":
-						arg := callexpr.Args[0]
-						switch arg := arg.(type) {
-						case *ast.CallExpr:
-							sel, ok := arg.Fun.(*ast.SelectorExpr)
-							if !ok {
-								continue
-							}
-							switch sel.X.(*ast.Ident).Name {
-							case "id":
-								// Call the id.ID() to construct the api path
-								apiPath, ok = a.apiPathFromID(method.Pkg, sel)
-							case "fmt":
-								// Call the fmt.Sprintf() to construct the api path
-								// e.g. '"%s/eventhubs/"'
-								formatString, _ := strconv.Unquote(arg.Args[0].(*ast.BasicLit).Value)
-								sel := arg.Args[1].(*ast.CallExpr).Fun.(*ast.SelectorExpr)
-								apiPath, ok = a.apiPathFromID(method.Pkg, sel)
-								apiPath = normalizeAPIPath(fmt.Sprintf(formatString, apiPath))
-							default:
-								panic(fmt.Sprintf("unexpected WithPath/WithPathParameters call happened at %s", method.Pkg.Fset.Position(callexpr.Pos())))
-							}
-						case *ast.BasicLit:
-							apiPath, _ = strconv.Unquote(arg.Value)
-							apiPath = normalizeAPIPath(apiPath)
-						default:
+						if len(callexpr.Args) == 0 {
 							continue
 						}
+						apiPath, err = apiPathFromCallArg(method.Pkg, prog.FuncValue(prepareFunc), callexpr)
+						if err != nil {
+							return false
+						}
 					case "AsGet":
 						opKind = OperationKindGet
 					case "AsPut":
@@ -245,6 +238,9 @@ func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodAutoRest(method SDKMetho
 			return true
 		}
 	})
+	if err != nil {
+		return nil, err
+	}
 	// Some API (e.g. track1 resources/resources.go) can accept the APIVersion as a parameter.
 	if apiVersion == "" {
 		apiVersion = "unknown"
@@ -262,17 +258,23 @@ func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodAutoRest(method SDKMetho
 			strings.Join(diags, ","))
 	}
 
-	return &APIOperation{
+	op := APIOperation{
 		Kind:    opKind,
 		Version: apiVersion,
-		Path:    apiPath,
+		Path:    normalizeAPIPath(apiPath),
+		ARMPath: parseARMPath(apiPath, opKind),
 		IsLRO:   isLRO,
-	}, nil
+	}
+	setAutoRestRequestDetails(&op, method.Pkg, prepareFuncDecl)
+	if responderDecl := methodDecl(method.Pkg, method.Recv, "responderFor"+methodName); responderDecl != nil {
+		op.ExpectedStatusCodes = autorestExpectedStatusCodes(method.Pkg, responderDecl)
+	}
+	return &SDKFunction{Operation: op, versionParam: versionParam}, nil
 }
 
 // findSDKOperationForMethodNative finds the native transport based method on the same receiver of the used SDK method.
 // If not found, returns nil APIOperation.
-func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodNative(method SDKMethod) (*APIOperation, error) {
+func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodNative(prog *ssa.Program, method SDKMethod) (*SDKFunction, error) {
 	var isLRO bool
 	methodName := method.MethodName
 	if strings.HasSuffix(methodName, "ThenPoll") {
@@ -310,11 +312,7 @@ func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodNative(method SDKMethod)
 	}
 
 	// Analyze the preparer function and gather the interested information.
-	var (
-		apiVersion string
-		apiPath    string
-		opKind     OperationKind
-	)
+	var apiVersion string
 
 	for _, f := range method.Pkg.Syntax {
 		if filepath.Base(method.Pkg.Fset.Position(f.Package).Filename) == "version.go" {
@@ -349,6 +347,9 @@ func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodNative(method SDKMethod)
 		}
 	}
 
+	if len(sdkFuncDecl.Body.List) == 0 {
+		return nil, nil
+	}
 	stmt, ok := sdkFuncDecl.Body.List[0].(*ast.AssignStmt)
 	if !ok {
 		return nil, nil
@@ -370,8 +371,49 @@ func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodNative(method SDKMethod)
 	if compType.Sel.Name != "RequestOptions" {
 		return nil, nil
 	}
+	op, err := requestOptionsOperation(method.Pkg, prog.FuncValue(sdkFunc), comp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Some API (e.g. track1 resources/resources.go) can accept the APIVersion as a parameter.
+	if apiVersion == "" {
+		apiVersion = "unknown"
+	}
+
+	var diags []string
+	if op.Path == "" {
+		diags = append(diags, "api path is not found")
+	}
+	if op.Kind == "" {
+		diags = append(diags, "API operation kind is not found")
+	}
+	if len(diags) != 0 {
+		return nil, fmt.Errorf("SDK operation info of the %s.%s is not complete: %s", method.Recv.Obj().Id(), method.MethodName,
+			strings.Join(diags, ","))
+	}
+
+	op.Version = apiVersion
+	op.IsLRO = isLRO
+	return &SDKFunction{Operation: op}, nil
+}
+
+func (a *SDKAnalyzerHashicorp) PackagePattern() *regexp.Regexp {
+	return a.pattern
+}
+
+// requestOptionsOperation returns the API operation (without the version and LRO info) from the client.RequestOptions
+// composite literal in the function fn, including the normalized API path, the ARMPath and the details of the request.
+func requestOptionsOperation(sdkpkg *packages.Package, fn *ssa.Function, comp *ast.CompositeLit) (APIOperation, error) {
+	var (
+		op      APIOperation
+		apiPath string
+	)
 	for _, expr := range comp.Elts {
-		expr := expr.(*ast.KeyValueExpr)
+		expr, ok := expr.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
 		exprKey, exprVal := expr.Key, expr.Value
 		ident, ok := exprKey.(*ast.Ident)
 		if !ok {
@@ -380,101 +422,144 @@ func (a *SDKAnalyzerHashicorp) findSDKOperationForMethodNative(method SDKMethod)
 		switch ident.Name {
 		// opKind
 		case "HttpMethod":
-			switch exprVal.(*ast.SelectorExpr).Sel.Name {
+			sel, ok := exprVal.(*ast.SelectorExpr)
+			if !ok {
+				return APIOperation{}, fmt.Errorf("unexpected HttpMethod value type at %s: %T", position(sdkpkg.Fset, exprVal.Pos()), exprVal)
+			}
+			switch sel.Sel.Name {
 			case "MethodGet":
-				opKind = OperationKindGet
+				op.Kind = OperationKindGet
 			case "MethodPost":
-				opKind = OperationKindPost
+				op.Kind = OperationKindPost
 			case "MethodPut":
-				opKind = OperationKindPut
+				op.Kind = OperationKindPut
 			case "MethodDelete":
-				opKind = OperationKindDelete
+				op.Kind = OperationKindDelete
 			case "MethodHead":
-				opKind = OperationKindHead
+				op.Kind = OperationKindHead
 			case "MethodPatch":
-				opKind = OperationKindPatch
+				op.Kind = OperationKindPatch
 			}
 		case "Path":
-			switch val := exprVal.(type) {
-			case *ast.CallExpr:
-				sel, ok := val.Fun.(*ast.SelectorExpr)
-				if !ok {
-					continue
-				}
-				switch sel.X.(*ast.Ident).Name {
-				// Call the id.ID() to construct the api path
-				case "id":
-					apiPath, ok = a.apiPathFromID(method.Pkg, sel)
-					// Call the fmt.Sprintf() to construct the api path
-				case "fmt":
-					// e.g. '"%s/eventhubs/"'
-					formatString, _ := strconv.Unquote(val.Args[0].(*ast.BasicLit).Value)
-					sel := val.Args[1].(*ast.CallExpr).Fun.(*ast.SelectorExpr)
-					apiPath, ok = a.apiPathFromID(method.Pkg, sel)
-					apiPath = normalizeAPIPath(fmt.Sprintf(formatString, apiPath))
-				default:
-					panic(fmt.Sprintf("unexpected Path value call happened at %s", method.Pkg.Fset.Position(val.Pos())))
+			v := storedValueAt(fn, expr.Colon)
+			if v == nil {
+				return APIOperation{}, fmt.Errorf("SSA value of the path at %s not found", position(sdkpkg.Fset, exprVal.Pos()))
+			}
+			apiPath = apiPathFromValue(v)
+		case "ContentType":
+			op.ContentType = constString(sdkpkg, exprVal)
+		case "ExpectedStatusCodes":
+			if complit, ok := exprVal.(*ast.CompositeLit); ok {
+				var codes []int
+				for _, elt := range complit.Elts {
+					if tv, ok := sdkpkg.TypesInfo.Types[elt]; ok && tv.Value != nil && tv.Value.Kind() == constant.Int {
+						code, _ := constant.Int64Val(tv.Value)
+						codes = append(codes, int(code))
+					}
 				}
-			case *ast.BasicLit:
-				apiPath, _ = strconv.Unquote(val.Value)
-			default:
-				panic(fmt.Sprintf("unexpected Path value type at: %s", method.Pkg.Fset.Position(val.Pos())))
+				op.ExpectedStatusCodes = newIntList(codes)
 			}
+		case "OptionsObject":
+			queryParameters, headers := optionsObjectParams(sdkpkg, exprVal)
+			op.QueryParameters = newStringList(queryParameters)
+			op.Headers = newStringList(headers)
 		}
 	}
-
-	// Some API (e.g. track1 resources/resources.go) can accept the APIVersion as a parameter.
-	if apiVersion == "" {
-		apiVersion = "unknown"
-	}
-
-	var diags []string
-	if apiPath == "" {
-		diags = append(diags, "api path is not found")
-	}
-	if opKind == "" {
-		diags = append(diags, "API operation kind is not found")
-	}
-	if len(diags) != 0 {
-		return nil, fmt.Errorf("SDK operation info of the %s.%s is not complete: %s", method.Recv.Obj().Id(), method.MethodName,
-			strings.Join(diags, ","))
-	}
-
-	return &APIOperation{
-		Kind:    opKind,
-		Version: apiVersion,
-		Path:    apiPath,
-		IsLRO:   isLRO,
-	}, nil
+	op.Path = normalizeAPIPath(apiPath)
+	op.ARMPath = parseARMPath(apiPath, op.Kind)
+	return op, nil
 }
 
-func (a *SDKAnalyzerHashicorp) PackagePattern() *regexp.Regexp {
-	return a.pattern
+// odataQueryParams maps the fields of the odata.Query to the query parameters, or the headers (prefixed by "header:").
+var odataQueryParams = map[string]string{
+	"ConsistencyLevel": "header:ConsistencyLevel",
+	"Count":            "$count",
+	"DeltaToken":       "$deltatoken",
+	"Expand":           "$expand",
+	"Filter":           "$filter",
+	"Format":           "$format",
+	"OrderBy":          "$orderby",
+	"Search":           "$search",
+	"Select":           "$select",
+	"Skip":             "$skip",
+	"Top":              "$top",
 }
 
-func (a SDKAnalyzerHashicorp) apiPathFromID(sdkpkg *packages.Package, idSelExpr *ast.SelectorExpr) (string, bool) {
-	idObj, ok := sdkpkg.TypesInfo.Uses[idSelExpr.X.(*ast.Ident)]
+// optionsObjectParams returns the query parameter keys and the header names that the options object (i.e. the
+// OptionsObject of the client.RequestOptions) can set, via its ToQuery(), ToHeaders() and ToOData() methods, e.g.
+//
+//	func (o GetOperationOptions) ToQuery() *client.QueryParams {
+//		out := client.QueryParams{}
+//		if o.Expand != nil {
+//			out.Append("$expand", fmt.Sprintf("%v", *o.Expand))
+//		}
+//		return &out
+//	}
+func optionsObjectParams(sdkpkg *packages.Package, expr ast.Expr) (queryParameters, headers []string) {
+	t := sdkpkg.TypesInfo.TypeOf(expr)
+	if t == nil {
+		return nil, nil
+	}
+	named, ok := typeutils.DereferenceR(t).(*types.Named)
 	if !ok {
-		return "", false
+		return nil, nil
 	}
-
-	idFunc := typeutils.NamedTypeMethodByName(idObj.Type().(*types.Named), idSelExpr.Sel.Name)
-	if idFunc == nil {
-		return "", false
+	appended := func(decl *ast.FuncDecl) []string {
+		var out []string
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Append" {
+				if k := constString(sdkpkg, call.Args[0]); k != "" {
+					out = append(out, k)
+				}
+			}
+			return true
+		})
+		return out
 	}
 
-	// The id.ID() can be defined either in the same sdk package, or the commonids package.
-	idPkgs := []*packages.Package{sdkpkg}
-	if a.commonidsPkg != nil {
-		idPkgs = append(idPkgs, a.commonidsPkg)
+	if decl := methodDecl(sdkpkg, named, "ToQuery"); decl != nil {
+		queryParameters = append(queryParameters, appended(decl)...)
 	}
-	idFuncDecl, err := typeutils.TypeFunc2DeclarationWithPkgs(idPkgs, idFunc)
-	if err != nil {
-		panic(fmt.Sprintf("failed to find the declaration of %s.%s", idObj.Id(), idFunc.Name()))
+	if decl := methodDecl(sdkpkg, named, "ToHeaders"); decl != nil {
+		headers = append(headers, appended(decl)...)
 	}
+	if decl := methodDecl(sdkpkg, named, "ToOData"); decl != nil {
+		// e.g. out.Expand = o.Expand, or odata.Query{Expand: o.Expand}
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			var field string
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				if len(n.Lhs) == 1 {
+					if sel, ok := n.Lhs[0].(*ast.SelectorExpr); ok {
+						field = sel.Sel.Name
+					}
+				}
+			case *ast.KeyValueExpr:
+				if ident, ok := n.Key.(*ast.Ident); ok {
+					field = ident.Name
+				}
+			}
+			if param, ok := odataQueryParams[field]; ok {
+				if header, ok := strings.CutPrefix(param, "header:"); ok {
+					headers = append(headers, header)
+				} else {
+					queryParameters = append(queryParameters, param)
+				}
+			}
+			return true
+		})
+	}
+	return queryParameters, headers
+}
 
-	apiPath, _ := strconv.Unquote(idFuncDecl.Body.List[0].(*ast.AssignStmt).Rhs[0].(*ast.BasicLit).Value)
-	apiPath = strings.ReplaceAll(apiPath, "%s", "{}")
-	apiPath = normalizeAPIPath(apiPath)
-	return apiPath, true
+// constString returns the constant string value of the expression, or empty string if it is not a constant string.
+func constString(pkg *packages.Package, expr ast.Expr) string {
+	if tv, ok := pkg.TypesInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value)
+	}
+	return ""
 }
@@ -9,24 +9,27 @@ import (
 
 func TestSDKAnalyzerHashicorpAutoRest(t *testing.T) {
 	t.Parallel()
-	pkgs, _, err := loadPackages("./internal/testmodule/hashicorpsdkuser/autorest", nil, []string{"."})
+	pkgs, err := loadPackages("./internal/testmodule/hashicorpsdkuser/autorest", []string{"."})
 	require.NoError(t, err)
 
-	a := NewSDKAnalyzerHashicorp(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/hashicorpsdk`), pkgs.Pkgs())
-	funcs, err := a.FindSDKAPIFuncs(pkgs)
+	a := NewSDKAnalyzerHashicorp(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/hashicorpsdk`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
 	require.NoError(t, err)
 
 	m := APIOperationMap{}
-	for _, op := range funcs {
-		m[op] = struct{}{}
+	for _, fn := range funcs {
+		m.Add(fn.Operation)
 	}
 	require.Equal(t,
 		APIOperations{
 			{
-				Kind:    OperationKindPost,
-				Version: "2025-04-01",
-				Path:    "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/UNLOCKDELETE",
-				IsLRO:   false,
+				Kind:                OperationKindPost,
+				Version:             "2025-04-01",
+				Path:                "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/UNLOCKDELETE",
+				ARMPath:             ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos", Action: "unlockDelete"},
+				IsLRO:               false,
+				ContentType:         "application/json; charset=utf-8",
+				ExpectedStatusCodes: "200",
 			},
 		},
 		m.ToList())
@@ -34,24 +37,37 @@ func TestSDKAnalyzerHashicorpAutoRest(t *testing.T) {
 
 func TestSDKAnalyzerHashicorpNative(t *testing.T) {
 	t.Parallel()
-	pkgs, _, err := loadPackages("./internal/testmodule/hashicorpsdkuser/native", nil, []string{"."})
+	pkgs, err := loadPackages("./internal/testmodule/hashicorpsdkuser/native", []string{"."})
 	require.NoError(t, err)
 
-	a := NewSDKAnalyzerHashicorp(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/hashicorpsdk`), pkgs.Pkgs())
-	funcs, err := a.FindSDKAPIFuncs(pkgs)
+	a := NewSDKAnalyzerHashicorp(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/hashicorpsdk`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
 	require.NoError(t, err)
 
 	m := APIOperationMap{}
-	for _, op := range funcs {
-		m[op] = struct{}{}
+	for _, fn := range funcs {
+		m.Add(fn.Operation)
 	}
 	require.Equal(t,
 		APIOperations{
 			{
-				Kind:    OperationKindPut,
-				Version: "2025-04-01",
-				Path:    "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}",
-				IsLRO:   true,
+				Kind:                OperationKindPut,
+				Version:             "2025-04-01",
+				Path:                "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}",
+				ARMPath:             ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos"},
+				IsLRO:               true,
+				ContentType:         "application/json; charset=utf-8",
+				ExpectedStatusCodes: "200,201,202",
+			},
+			{
+				Kind:                OperationKindGet,
+				Version:             "2025-04-01",
+				Path:                "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/BARS",
+				ARMPath:             ARMPath{Scope: ARMScopeResourceGroup, ProviderNamespace: "Microsoft.Foo", ResourceTypes: "foos/bars"},
+				ContentType:         "application/json; charset=utf-8",
+				QueryParameters:     "$count,$expand,$filter",
+				Headers:             "If-Match",
+				ExpectedStatusCodes: "200",
 			},
 		},
 		m.ToList())
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"github.com/magodo/aztfo/typeutils"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+)
+
+type SDKAnalyzerMSGraph struct {
+	pattern *regexp.Regexp
+}
+
+// NewSDKAnalyzerMSGraph builds a SDK analyzer for the Microsoft Graph SDK of Hashicorp (i.e. the "microsoft-graph" of the
+// "github.com/hashicorp/go-azure-sdk"). The pattern specifies the regexp pattern of the SDK package path.
+func NewSDKAnalyzerMSGraph(pattern *regexp.Regexp) *SDKAnalyzerMSGraph {
+	return &SDKAnalyzerMSGraph{
+		pattern: pattern,
+	}
+}
+
+func (a *SDKAnalyzerMSGraph) Name() string {
+	return "MSGraph"
+}
+
+func (a *SDKAnalyzerMSGraph) PackagePattern() *regexp.Regexp {
+	return a.pattern
+}
+
+func (a *SDKAnalyzerMSGraph) FindSDKAPIFuncs(pkgs Packages, diags *Diagnostics) (SDKFunctions, error) {
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+	prog := pkgs[0].ssa.Prog
+	usedSdkMethods, err := usedSDKMethods(a, pkgs, diags)
+	if err != nil {
+		return nil, err
+	}
+
+	res := SDKFunctions{}
+	for method := range usedSdkMethods {
+		apiOp, err := a.findSDKOperationForMethod(prog, method)
+		if err != nil {
+			if err := diags.Handle(Diagnostic{SDKMethod: sdkMethodName(method), Position: position(method.Pkg.Fset, method.Recv.Obj().Pos()), Reason: fmt.Sprintf("failed to find SDK operation: %v", err)}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if apiOp == nil {
+			continue
+		}
+
+		ssaFunc := prog.LookupMethod(method.Recv, method.Pkg.Types, method.MethodName)
+		if ssaFunc == nil {
+			return nil, fmt.Errorf("failed to find the ssa function of %s.%s", method.Recv.Obj().Id(), method.MethodName)
+		}
+
+		res[ssaFunc] = SDKFunction{Operation: *apiOp}
+	}
+
+	return res, nil
+}
+
+// findSDKOperationForMethod finds the method that builds the client.RequestOptions on the same receiver of the used SDK method.
+// If not found, returns nil APIOperation.
+func (a *SDKAnalyzerMSGraph) findSDKOperationForMethod(prog *ssa.Program, method SDKMethod) (*APIOperation, error) {
+	methodName := method.MethodName
+	if strings.HasSuffix(methodName, "CompleteMatchingPredicate") {
+		// "LIST"
+		methodName = strings.TrimSuffix(methodName, "CompleteMatchingPredicate")
+	} else if strings.HasSuffix(methodName, "Complete") {
+		// "LIST"
+		methodName = strings.TrimSuffix(methodName, "Complete")
+	}
+
+	sdkFunc := typeutils.NamedTypeMethodByName(method.Recv, methodName)
+	if sdkFunc == nil {
+		return nil, nil
+	}
+	sdkFuncDecl, err := typeutils.TypeFunc2DeclarationWithPkg(method.Pkg, sdkFunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the declaration of %s.%s", method.Recv.Obj().Id(), methodName)
+	}
+
+	if len(sdkFuncDecl.Body.List) == 0 {
+		return nil, nil
+	}
+	stmt, ok := sdkFuncDecl.Body.List[0].(*ast.AssignStmt)
+	if !ok || len(stmt.Rhs) != 1 {
+		return nil, nil
+	}
+	comp, ok := stmt.Rhs[0].(*ast.CompositeLit)
+	if !ok {
+		return nil, nil
+	}
+	compType, ok := comp.Type.(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil
+	}
+	if v, ok := compType.X.(*ast.Ident); !ok || v.Name != "client" || compType.Sel.Name != "RequestOptions" {
+		return nil, nil
+	}
+
+	op, err := requestOptionsOperation(method.Pkg, prog.FuncValue(sdkFunc), comp)
+	if err != nil {
+		return nil, err
+	}
+
+	var diags []string
+	if op.Path == "" {
+		diags = append(diags, "api path is not found")
+	}
+	if op.Kind == "" {
+		diags = append(diags, "API operation kind is not found")
+	}
+	if len(diags) != 0 {
+		return nil, fmt.Errorf("SDK operation info of the %s.%s is not complete: %s", method.Recv.Obj().Id(), method.MethodName,
+			strings.Join(diags, ","))
+	}
+
+	// The Microsoft Graph API paths are not ARM API paths, even if they look alike (e.g. "/subscriptions/{id}").
+	op.ARMPath = ARMPath{}
+	op.Version = msgraphAPIVersion(method.Pkg)
+	return &op, nil
+}
+
+// msgraphAPIVersion returns the Graph API version (i.e. "v1.0" or "beta") of the SDK package, which is specified when
+// constructing the client, e.g.
+//
+//	client, err := msgraph.NewMsGraphClient(sdkApi, "application", msgraph.VersionOnePointZero)
+//
+// If there is no such constructor call, the first referenced API version constant (in the source order) is used.
+// It returns "unknown" if not found.
+func msgraphAPIVersion(sdkpkg *packages.Package) string {
+	var ctorVersion, firstVersion string
+	for _, file := range sdkpkg.Syntax {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if ctorVersion != "" {
+				return false
+			}
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				var fn types.Object
+				switch fun := n.Fun.(type) {
+				case *ast.SelectorExpr:
+					fn = sdkpkg.TypesInfo.Uses[fun.Sel]
+				case *ast.Ident:
+					fn = sdkpkg.TypesInfo.Uses[fun]
+				}
+				if _, ok := fn.(*types.Func); !ok || !isMSGraphClientPkg(fn.Pkg()) {
+					return true
+				}
+				for _, arg := range n.Args {
+					if v := msgraphAPIVersionOf(sdkpkg.TypesInfo, arg); v != "" {
+						ctorVersion = v
+						return false
+					}
+				}
+			case *ast.Ident:
+				if firstVersion == "" {
+					firstVersion = msgraphAPIVersionOf(sdkpkg.TypesInfo, n)
+				}
+			}
+			return true
+		})
+		if ctorVersion != "" {
+			return ctorVersion
+		}
+	}
+	if firstVersion != "" {
+		return firstVersion
+	}
+	return "unknown"
+}
+
+// msgraphAPIVersionOf returns the value of the expression if it references an API version constant of the msgraph client
+// package (e.g. "msgraph.VersionBeta"), or empty otherwise.
+func msgraphAPIVersionOf(info *types.Info, expr ast.Expr) string {
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		expr = sel.Sel
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	c, ok := info.Uses[ident].(*types.Const)
+	if !ok || c.Val().Kind() != constant.String {
+		return ""
+	}
+	named, ok := c.Type().(*types.Named)
+	if !ok || named.Obj().Name() != "ApiVersion" || !isMSGraphClientPkg(named.Obj().Pkg()) {
+		return ""
+	}
+	return constant.StringVal(c.Val())
+}
+
+// isMSGraphClientPkg tells whether the package is the msgraph client package of the go-azure-sdk.
+func isMSGraphClientPkg(pkg *types.Package) bool {
+	return pkg != nil && strings.HasSuffix(pkg.Path(), "/client/msgraph")
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDKAnalyzerMSGraph(t *testing.T) {
+	t.Parallel()
+	pkgs, err := loadPackages("./internal/testmodule/msgraphsdkuser", []string{"."})
+	require.NoError(t, err)
+
+	a := NewSDKAnalyzerMSGraph(regexp.MustCompile(`github.com/magodo/aztfo/internal/testmodule/msgraphsdk`))
+	funcs, err := a.FindSDKAPIFuncs(pkgs, nil)
+	require.NoError(t, err)
+
+	m := APIOperationMap{}
+	for _, fn := range funcs {
+		m.Add(fn.Operation)
+	}
+	require.Equal(t,
+		APIOperations{
+			{Kind: OperationKindGet, Version: "v1.0", Path: "/APPLICATIONS", ContentType: "application/json; charset=utf-8", ExpectedStatusCodes: "200"},
+			{Kind: OperationKindGet, Version: "v1.0", Path: "/APPLICATIONS/{}", ContentType: "application/json; charset=utf-8", ExpectedStatusCodes: "200"},
+			{Kind: OperationKindPatch, Version: "v1.0", Path: "/APPLICATIONS/{}", ContentType: "application/json; charset=utf-8", ExpectedStatusCodes: "204"},
+			{Kind: OperationKindPost, Version: "v1.0", Path: "/APPLICATIONS/{}/OWNERS/$REF", ContentType: "application/json; charset=utf-8", ExpectedStatusCodes: "204"},
+			{Kind: OperationKindDelete, Version: "beta", Path: "/GROUPS/{}", ContentType: "application/json; charset=utf-8", ExpectedStatusCodes: "204"},
+		},
+		m.ToList())
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIOperationsLess(t *testing.T) {
+	t.Parallel()
+	var (
+		mgmt = APIOperation{Kind: OperationKindGet, Version: "7.4", Path: "/SECRETS/{}"}
+		data = APIOperation{Kind: OperationKindGet, Version: "7.4", Path: "/SECRETS/{}", Plane: PlaneData}
+	)
+
+	ops := APIOperations{mgmt, data, mgmt}
+	// An operation is not less than itself.
+	require.False(t, ops.Less(0, 2))
+	require.True(t, ops.Less(0, 1))
+	require.False(t, ops.Less(1, 0))
+
+	sort.Sort(ops)
+	require.Equal(t, APIOperations{mgmt, mgmt, data}, ops)
+	ops = APIOperations{data, mgmt}
+	sort.Sort(ops)
+	require.Equal(t, APIOperations{mgmt, data}, ops)
+}
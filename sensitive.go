@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// SensitiveRule tags the API operations that expose the credentials, e.g. the access keys, secrets and SAS tokens.
+type SensitiveRule struct {
+	// Kinds are the operation kinds (e.g. "POST") that the rule applies to. Empty means any kind.
+	Kinds []OperationKind `yaml:"kinds"`
+	// PathPattern is the regexp matched against the normalized API path (i.e. upper cased, with "{}" as the placeholders),
+	// e.g. "/LISTKEYS$".
+	PathPattern string `yaml:"path_pattern"`
+	// Reason tells why the matched API operations are sensitive, e.g. "lists the keys".
+	Reason string `yaml:"reason"`
+
+	pathRegexp *regexp.Regexp
+}
+
+type SensitiveRules []SensitiveRule
+
+// builtinSensitiveRules are the sensitive rules that always apply, in addition to the ones of the config.
+var builtinSensitiveRules = SensitiveRules{
+	// e.g. listKeys, listAdminKeys, listQueryKeys
+	{PathPattern: `/LIST\w*KEYS?$`, Reason: "lists the keys"},
+	// e.g. listSecrets
+	{PathPattern: `/LIST\w*SECRETS?$`, Reason: "lists the secrets"},
+	// e.g. listCredentials, listClusterAdminCredential
+	{PathPattern: `/LIST\w*CREDENTIALS?$`, Reason: "lists the credentials"},
+	// e.g. listConnectionStrings
+	{PathPattern: `/LIST\w*CONNECTIONSTRINGS?$`, Reason: "lists the connection strings"},
+	// e.g. regenerateKey, regenerateAccessKey, regeneratePrimaryKey
+	{Kinds: []OperationKind{OperationKindPost}, PathPattern: `/REGENERATE\w*KEYS?$`, Reason: "regenerates the keys"},
+	// e.g. getSasToken, listAccountSas, listServiceSas
+	{Kinds: []OperationKind{OperationKindPost}, PathPattern: `/(GET\w*SASTOKENS?|LIST\w*SAS)$`, Reason: "generates the SAS tokens"},
+	// The data-plane operations of the Key Vault secrets
+	{Kinds: []OperationKind{OperationKindGet}, PathPattern: `^/SECRETS/\{\}`, Reason: "reads the secret values"},
+}
+
+func init() {
+	for i := range builtinSensitiveRules {
+		builtinSensitiveRules[i].pathRegexp = regexp.MustCompile(builtinSensitiveRules[i].PathPattern)
+	}
+}
+
+// match tells whether the rule applies to the API operation.
+func (rule SensitiveRule) match(op APIOperation) bool {
+	if len(rule.Kinds) != 0 && !slices.Contains(rule.Kinds, op.Kind) {
+		return false
+	}
+	return rule.pathRegexp.MatchString(op.Path)
+}
+
+// reasons returns the reasons of the rules that apply to the API operation, in the order of the rules. It returns nil if
+// the API operation is not sensitive.
+func (rules SensitiveRules) reasons(op APIOperation) []string {
+	var reasons []string
+	for _, rule := range rules {
+		if rule.match(op) && !slices.Contains(reasons, rule.Reason) {
+			reasons = append(reasons, rule.Reason)
+		}
+	}
+	return reasons
+}
+
+// SensitiveOperation is a sensitive API operation, with the reasons.
+type SensitiveOperation struct {
+	Operation APIOperation `json:"operation"`
+	Reasons   []string     `json:"reasons"`
+}
+
+type SensitiveOperations []SensitiveOperation
+
+// classify returns the sensitive API operations among the ops, in the same order.
+func (rules SensitiveRules) classify(ops APIOperations) SensitiveOperations {
+	var out SensitiveOperations
+	for _, op := range ops {
+		if reasons := rules.reasons(op); len(reasons) != 0 {
+			out = append(out, SensitiveOperation{Operation: op, Reasons: reasons})
+		}
+	}
+	return out
+}
+
+// SensitiveMatch is a Terraform resource verb that calls the sensitive API operations.
+type SensitiveMatch struct {
+	Id         ResourceId          `json:"id"`
+	Verb       string              `json:"verb"`
+	Operations SensitiveOperations `json:"operations"`
+}
+
+// sensitiveReport looks up the resource verbs that call the sensitive API operations, in the order of the resources and verbs.
+func sensitiveReport(results Results, rules SensitiveRules) []SensitiveMatch {
+	var matches []SensitiveMatch
+	for _, res := range results {
+		for _, vo := range res.VerbOperations() {
+			if sops := rules.classify(vo.Operations); len(sops) != 0 {
+				matches = append(matches, SensitiveMatch{Id: res.Id, Verb: vo.Verb, Operations: sops})
+			}
+		}
+	}
+	return matches
+}
+
+// writeSensitiveText writes the sensitive report in a human readable form.
+func writeSensitiveText(w io.Writer, matches []SensitiveMatch) {
+	for i, m := range matches {
+		if i == 0 || matches[i-1].Id != m.Id {
+			fmt.Fprintf(w, "%s\n", m.Id)
+		}
+		fmt.Fprintf(w, "  %s:\n", m.Verb)
+		for _, sop := range m.Operations {
+			fmt.Fprintf(w, "    %s %s %s: %s\n", sop.Operation.Kind, sop.Operation.Path, sop.Operation.Version, strings.Join(sop.Reasons, ", "))
+		}
+	}
+}
+
+func runSensitive(args []string) error {
+	fs := flag.NewFlagSet("sensitive", flag.ExitOnError)
+	flagConfig := fs.String("config", DefaultProfile, "The built-in profile name or the config file path, whose sensitive_operations rules are used in addition to the built-in ones")
+	flagJSON := fs.Bool("json", false, "Output the report in JSON, instead of the human readable text")
+	fs.Usage = func() {
+		fmt.Println(`Usage: aztfo sensitive [options] <report>
+
+Reports the Terraform resources and verbs that call the sensitive API operations, which expose the credentials (e.g. the
+access keys, secrets and SAS tokens), with the reasons.
+
+Arguments:
+  - report
+	The aztfo output file, or "-" to read from the stdin.
+
+Options:`)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expect exactly one argument, got=%d", fs.NArg())
+	}
+
+	cfg, err := loadConfig(*flagConfig)
+	if err != nil {
+		return err
+	}
+	results, err := readResults(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	matches := sensitiveReport(results, cfg.sensitiveRules())
+	if !*flagJSON {
+		writeSensitiveText(os.Stdout, matches)
+		return nil
+	}
+	if matches == nil {
+		matches = []SensitiveMatch{}
+	}
+	b, err := json.MarshalIndent(matches, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal the output: %v", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
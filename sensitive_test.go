@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSensitiveClassify(t *testing.T) {
+	t.Parallel()
+	rules := defaultConfig(t).sensitiveRules()
+
+	cases := []struct {
+		name   string
+		op     APIOperation
+		expect []string
+	}{
+		{
+			name:   "list keys",
+			op:     APIOperation{Kind: OperationKindPost, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.STORAGE/STORAGEACCOUNTS/{}/LISTKEYS"},
+			expect: []string{"lists the keys"},
+		},
+		{
+			name:   "list admin credential",
+			op:     APIOperation{Kind: OperationKindPost, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.CONTAINERSERVICE/MANAGEDCLUSTERS/{}/LISTCLUSTERADMINCREDENTIAL"},
+			expect: []string{"lists the credentials"},
+		},
+		{
+			name:   "list query keys of GET",
+			op:     APIOperation{Kind: OperationKindGet, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.SEARCH/SEARCHSERVICES/{}/LISTQUERYKEYS"},
+			expect: []string{"lists the keys"},
+		},
+		{
+			name:   "regenerate key",
+			op:     APIOperation{Kind: OperationKindPost, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.STORAGE/STORAGEACCOUNTS/{}/REGENERATEKEY"},
+			expect: []string{"regenerates the keys"},
+		},
+		{
+			name:   "SAS token",
+			op:     APIOperation{Kind: OperationKindPost, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.STORAGE/STORAGEACCOUNTS/{}/LISTACCOUNTSAS"},
+			expect: []string{"generates the SAS tokens"},
+		},
+		{
+			name:   "key vault secret",
+			op:     APIOperation{Kind: OperationKindGet, Path: "/SECRETS/{}/{}", Plane: PlaneData, Endpoint: "https://{}.vault.azure.net"},
+			expect: []string{"reads the secret values"},
+		},
+		{
+			name: "key vault secret deletion",
+			op:   APIOperation{Kind: OperationKindDelete, Path: "/SECRETS/{}", Plane: PlaneData, Endpoint: "https://{}.vault.azure.net"},
+		},
+		{
+			name: "regular resource",
+			op:   APIOperation{Kind: OperationKindGet, Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.STORAGE/STORAGEACCOUNTS/{}"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expect, rules.reasons(c.op))
+		})
+	}
+}
+
+func TestSensitiveConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	const base = `
+service_package_pattern: foo
+resource: {registrations: [Registration], untyped_sdk_package: pluginsdk, typed_sdk_package: sdk}
+`
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(base+`
+sensitive_operations:
+  - kinds: [POST]
+    path_pattern: /FETCHTOKEN$
+    reason: fetches the access tokens
+  - path_pattern: /LISTKEYS$
+    reason: lists the storage account keys
+`), 0644))
+	cfg, err := loadConfig(path)
+	require.NoError(t, err)
+	rules := cfg.sensitiveRules()
+	require.Len(t, rules, len(builtinSensitiveRules)+2)
+	require.Equal(t, []string{"fetches the access tokens"}, rules.reasons(APIOperation{Kind: OperationKindPost, Path: "/FOOS/{}/FETCHTOKEN"}))
+	require.Empty(t, rules.reasons(APIOperation{Kind: OperationKindGet, Path: "/FOOS/{}/FETCHTOKEN"}))
+	require.Equal(t, []string{"lists the keys", "lists the storage account keys"}, rules.reasons(APIOperation{Kind: OperationKindPost, Path: "/FOOS/{}/LISTKEYS"}))
+	// The built-in rules are not affected.
+	require.Len(t, builtinSensitiveRules.reasons(APIOperation{Kind: OperationKindPost, Path: "/FOOS/{}/LISTKEYS"}), 1)
+
+	for _, body := range []string{
+		"sensitive_operations: [{path_pattern: /FETCHTOKEN$}]",
+		"sensitive_operations: [{reason: foo}]",
+		`sensitive_operations: [{path_pattern: "(", reason: foo}]`,
+	} {
+		path := filepath.Join(dir, "invalid.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(base+body), 0644))
+		_, err := loadConfig(path)
+		require.Error(t, err, body)
+	}
+}
+
+func TestSensitiveReport(t *testing.T) {
+	t.Parallel()
+	var (
+		opGet      = APIOperation{Kind: OperationKindGet, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}"}
+		opListKeys = APIOperation{Kind: OperationKindPost, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/LISTKEYS"}
+		opRegen    = APIOperation{Kind: OperationKindPost, Version: "2025-04-01", Path: "/SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/REGENERATEKEY"}
+		opSecret   = APIOperation{Kind: OperationKindGet, Version: "7.4", Path: "/SECRETS/{}/{}", Plane: PlaneData, Endpoint: "https://{}.vault.azure.net"}
+	)
+	results := Results{
+		{
+			Id:   ResourceId{Name: "azurerm_bar", Kind: ResourceKindDataSource},
+			Read: APIOperations{opSecret},
+		},
+		{
+			Id:     ResourceId{Name: "azurerm_baz"},
+			Create: APIOperations{opGet},
+			Read:   APIOperations{opGet},
+		},
+		{
+			Id:     ResourceId{Name: "azurerm_foo"},
+			Create: APIOperations{opGet, opListKeys},
+			Read:   APIOperations{opGet, opListKeys},
+			Update: APIOperations{opRegen},
+		},
+	}
+
+	matches := sensitiveReport(results, builtinSensitiveRules)
+	require.Equal(t, []SensitiveMatch{
+		{Id: ResourceId{Name: "azurerm_bar", Kind: ResourceKindDataSource}, Verb: "read", Operations: SensitiveOperations{{Operation: opSecret, Reasons: []string{"reads the secret values"}}}},
+		{Id: ResourceId{Name: "azurerm_foo"}, Verb: "create", Operations: SensitiveOperations{{Operation: opListKeys, Reasons: []string{"lists the keys"}}}},
+		{Id: ResourceId{Name: "azurerm_foo"}, Verb: "read", Operations: SensitiveOperations{{Operation: opListKeys, Reasons: []string{"lists the keys"}}}},
+		{Id: ResourceId{Name: "azurerm_foo"}, Verb: "update", Operations: SensitiveOperations{{Operation: opRegen, Reasons: []string{"regenerates the keys"}}}},
+	}, matches)
+
+	var buf bytes.Buffer
+	writeSensitiveText(&buf, matches)
+	require.Equal(t, `azurerm_bar (DS)
+  read:
+    GET /SECRETS/{}/{} 7.4: reads the secret values
+azurerm_foo
+  create:
+    POST /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/LISTKEYS 2025-04-01: lists the keys
+  read:
+    POST /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/LISTKEYS 2025-04-01: lists the keys
+  update:
+    POST /SUBSCRIPTIONS/{}/RESOURCEGROUPS/{}/PROVIDERS/MICROSOFT.FOO/FOOS/{}/REGENERATEKEY 2025-04-01: regenerates the keys
+`, buf.String())
+}
@@ -51,16 +51,33 @@ main() {
     command -v terraform > /dev/null || die '"terraform" not installed'
     command -v jq > /dev/null || die '"jq" not installed'
 
-    ds_diff="$(diff <(terraform -chdir=$wsp_dir providers schema -json | jq '.provider_schemas."registry.terraform.io/hashicorp/azurerm".data_source_schemas | keys | .[]') <(jq '[.[] | select(.id.is_data_source == true)] | .[].id.name' < $file))"
+    schema="$(terraform -chdir=$wsp_dir providers schema -json | jq '.provider_schemas."registry.terraform.io/hashicorp/azurerm"')"
+
+    ds_diff="$(diff <(jq '.data_source_schemas | keys | .[]' <<< "$schema") <(jq '[.[] | select(.id.kind == "data_source")] | .[].id.name' < $file))"
     if [[ -n $ds_diff ]]; then
         die "Diff data sources (expect vs actual):\n$ds_diff"
     fi
 
-    res_diff="$(diff <(terraform -chdir=$wsp_dir providers schema -json | jq '.provider_schemas."registry.terraform.io/hashicorp/azurerm".resource_schemas | keys | .[]') <(jq '[.[] | select(.id.is_data_source == false)] | .[].id.name' < $file))"
+    res_diff="$(diff <(jq '.resource_schemas | keys | .[]' <<< "$schema") <(jq '[.[] | select(.id.kind == "resource")] | .[].id.name' < $file))"
     if [[ -n $res_diff ]]; then
         die "Diff resources (expect vs actual):\n$res_diff"
 
     fi
+
+    eph_diff="$(diff <(jq '.ephemeral_resource_schemas // {} | keys | .[]' <<< "$schema") <(jq '[.[] | select(.id.kind == "ephemeral")] | .[].id.name' < $file))"
+    if [[ -n $eph_diff ]]; then
+        die "Diff ephemeral resources (expect vs actual):\n$eph_diff"
+    fi
+
+    list_diff="$(diff <(jq '.list_resource_schemas // {} | keys | .[]' <<< "$schema") <(jq '[.[] | select(.id.kind == "list")] | .[].id.name' < $file))"
+    if [[ -n $list_diff ]]; then
+        die "Diff list resources (expect vs actual):\n$list_diff"
+    fi
+
+    action_diff="$(diff <(jq '.action_schemas // {} | keys | .[]' <<< "$schema") <(jq '[.[] | select(.id.kind == "action")] | .[].id.name' < $file))"
+    if [[ -n $action_diff ]]; then
+        die "Diff actions (expect vs actual):\n$action_diff"
+    fi
 }
 
 main "$@"